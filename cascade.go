@@ -18,42 +18,108 @@ package cascade
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Version is the current version of Cascade.
-const Version string = "1.0.0"
+const Version string = "2.0.0"
 
 // Cascade is the core structure of the cascade package. It contains all of the
 // non-public resources used to maintain all tracked routines.
 type Cascade struct {
-	parent      *Cascade
-	children    map[*Cascade]interface{}
-	muChildren  sync.Mutex
-	dying       chan interface{}
-	onceDying   sync.Once
-	dead        chan interface{}
-	onceDead    sync.Once
-	done        chan interface{}
-	onceDone    sync.Once
-	isDead      bool
-	muDead      sync.RWMutex
-	actions     []func()
-	muActions   sync.Mutex
-	onceActions sync.Once
-	tracked     int
-	muTracked   sync.RWMutex
-	ctx         context.Context                    // A context that will Kill this Cascade
-	trackedCtx  map[context.Context]trackedContext // Contexts that will be cancelled when this cascade gets Killed
-	muCtx       sync.Mutex
-	err         error
-	muErr       sync.Mutex
+	id            uint64                  // Unique within the process, assigned at creation
+	parent        atomic.Pointer[Cascade] // Set by ChildCascade and only changed again by Detach
+	children      map[*Cascade]interface{}
+	childWait     []chan childEvent // Waiting callers of WaitChildEvent
+	childErrs     []collectedErrors // Errors of removed children, see CollectErrors
+	muChildren    rankedMutex[rankChildren]
+	dying         chan struct{}
+	onceDying     sync.Once
+	dead          chan struct{}
+	onceDead      sync.Once
+	done          chan struct{}
+	isDead        atomic.Bool   // Only ever set while holding muDead, so deathMode is visible with it
+	deathMode     teardownMode  // Whether the Cascade was killed or cancelled, set along with isDead
+	tdStart       time.Time     // When the teardown started
+	tdDuration    time.Duration // How long the teardown took, set once it is complete
+	reasonWait    []chan error  // Waiting callers of DyingReason
+	muDead        rankedRWMutex[rankDead]
+	actions       actionList      // Actions registered with DoOnKill and DoFirstOnKill
+	cancelActions actionList      // Actions registered with DoOnCancel and DoFirstOnCancel
+	alwaysActions actionList      // Actions registered with DoAlways and DoFirstAlways
+	lastSeq       atomic.Int64    // Sequence number of the last action added to the end of the actions
+	firstSeq      atomic.Int64    // Sequence number of the last action added to the front of the actions
+	onceKeys      map[string]bool // Keys of the actions registered with DoOnKillOnce
+	skipped       int             // Number of actions that were skipped because the Cascade was cancelled
+	stepActions   chan struct{}   // Testing hook: when set, each action waits for a receive before running
+	preStop       []func()        // Hooks registered with DoBeforeKill
+	preState      actionState     // Whether the pre-stop hooks have been run or skipped
+	critical      []func()        // Actions registered with DoCritical
+	critState     actionState     // Whether the critical actions have been run
+	stateHooks    []func(State)   // Hooks registered with OnStateChange
+	muActions     rankedMutex[rankActions]
+	kills         atomic.Int64 // Cascades killed in the tree of a root, see KillCount
+	cancels       atomic.Int64 // Cascades cancelled in the tree of a root, see CancelCount
+	tracked       atomic.Int64 // Changed under muTracked.RLock, so holding the write lock freezes it
+	peakTracked   atomic.Int64 // Highest value that tracked has reached
+	watchers      atomic.Int64 // Internal watcher goroutines, they hold off dead like tracked but are not reported
+	leakTrack     atomic.Bool  // Set along with leakAfter, Mark then records its call site in marks
+	marks         []markSite   // Call sites of the outstanding marks while leak detection is enabled
+	muMarks       rankedMutex[rankMarks]
+	launched      atomic.Bool // The Cascade was created by Go (or a variant) to track a function
+	draining      atomic.Bool // New children are rejected, see Drain
+	rejected      bool        // Created while the parent was draining, nothing will be launched on it
+	quiesced      bool        // New marks will wait until the Cascade is no longer quiesced
+	condTracked   *sync.Cond  // Signalled whenever tracked or quiesced changes
+	muTracked     rankedRWMutex[rankTracked]
+	ctx           context.Context                    // A context that will Kill this Cascade
+	trackedCtx    map[context.Context]trackedContext // Contexts that will be cancelled when this cascade gets Killed
+	inheritCtx    bool                               // Tracked contexts fall back to the values of ctx
+	lastCtx       uint64                             // Creation order of the last tracked context
+	ctxCancels    []context.CancelFunc               // Releases the Contexts created for the Cascade itself, such as by WithTimeout
+	muCtx         rankedMutex[rankCtx]
+	err           error
+	muErr         rankedMutex[rankErr]
+	name          string // A purely descriptive name used when identifying the Cascade
+	muName        rankedRWMutex[rankName]
+	meta          map[string]interface{}      // Metadata attached with SetMeta
+	values        map[interface{}]interface{} // Values stored with SetValue, guarded by muMeta
+	muMeta        rankedRWMutex[rankMeta]
+	logger        Logger                 // Receives diagnostic messages, may be nil
+	strict        bool                   // Report likely misuse through the logger
+	verbose       bool                   // Report lifecycle events through the logger
+	slowChild     time.Duration          // Report children that take longer than this to tear down
+	watchdog      time.Duration          // Report when tracked goroutines take longer than this to exit
+	leakAfter     time.Duration          // Report the outstanding marks after this long, see SetLeakDetection
+	leakPanic     bool                   // Panic instead of only reporting when leak detection fires
+	preOnCancel   bool                   // Run the pre-stop hooks when cancelled as well
+	sequenced     bool                   // Order the actions by their sequence numbers before running them
+	parallel      bool                   // Run the actions concurrently rather than one after the other
+	actionTimeout time.Duration          // How long an action may run before the remaining actions are dropped
+	joinErrors    bool                   // KillWithError and CancelWithError join onto an existing error
+	panicPolicy   PanicPolicy            // How panics in tracked functions are handled
+	killTimeout   time.Duration          // How long Close waits for the Cascade to become dead, 0 waits forever
+	maxCtx        int                    // Maximum number of tracked contexts, 0 is unbounded
+	signals       map[os.Signal]struct{} // Signals registered with NotifyOnSignal
+	muConfig      rankedRWMutex[rankConfig]
 }
 
+// lastID is the ID that was most recently assigned to a Cascade
+var lastID atomic.Uint64
+
 // trackedContext struct manages any tracked Context items since we need to also track their "cancel" function.
 type trackedContext struct {
-	context context.Context
-	cancel  func()
+	context       context.Context
+	cancel        context.CancelCauseFunc
+	bidirectional bool   // Cancelling the parent of the context will kill the Cascade
+	order         uint64 // Creation order, used to evict the oldest context (see SetMaxTrackedContexts)
 }
 
 // RootCascade creates a new Cascade that is fully-initialized and ready to go.
@@ -63,85 +129,371 @@ type trackedContext struct {
 //
 // When calling `KillAllWithError` or `CancelAllWithError`, the `RootCascade` Cascade is the only one that will
 // receive the passed error.
-func RootCascade() *Cascade {
-	return &Cascade{
-		nil,
-		make(map[*Cascade]interface{}),
-		sync.Mutex{},
-		make(chan interface{}, 0),
-		sync.Once{},
-		make(chan interface{}, 0),
-		sync.Once{},
-		make(chan interface{}, 0),
-		sync.Once{},
-		false,
-		sync.RWMutex{},
-		make([]func(), 0),
-		sync.Mutex{},
-		sync.Once{},
-		0,
-		sync.RWMutex{},
-		nil,
-		make(map[context.Context]trackedContext, 0),
-		sync.Mutex{},
-		nil,
-		sync.Mutex{},
-	}
-}
-
-// Executes queued actions
+//
+// Options (such as `WithName`) can be passed to configure the Cascade as it is created.
+func RootCascade(opts ...Option) *Cascade {
+	c := &Cascade{}
+	c.init()
+	c.apply(opts)
+	return c
+}
+
+// RootCascadeNamed creates a new `RootCascade` with the provided name (see `SetName`).
+func RootCascadeNamed(name string) *Cascade {
+	c := RootCascade()
+	c.SetName(name)
+	return c
+}
+
+// Initializes every field of a zeroed Cascade that does not have a usable zero value
+func (c *Cascade) init() {
+	c.id = lastID.Add(1)
+	c.children = make(map[*Cascade]interface{})
+	c.dying = make(chan struct{})
+	c.dead = make(chan struct{})
+	c.done = make(chan struct{}, 0)
+	c.actions.actions = make([]killAction, 0)
+	c.condTracked = sync.NewCond(&c.muTracked)
+	c.trackedCtx = make(map[context.Context]trackedContext, 0)
+}
+
+// killAction is a function registered with DoOnKill, DoOnCancel or one of their variants.
+type killAction struct {
+	run func()
+	seq int64 // Increases with every DoOnKill and decreases with every DoFirstOnKill (and variants)
+}
+
+// ActionID identifies an action registered with `DoOnKill` or `DoFirstOnKill`, see `RemoveAction`.
+type ActionID int64
+
+// actionList holds the actions that are run for one way of tearing down a Cascade. All fields are guarded
+// by the muActions of the Cascade that owns the list.
+type actionList struct {
+	actions []killAction
+	state   actionState // Whether the actions are pending, running or finished
+	next    int         // Index of the next action to run while the actions are running
+	once    sync.Once
+}
+
+// teardownMode is the way a Cascade is being torn down.
+type teardownMode int
+
+const (
+	modeKill   teardownMode = iota // Killed, see Kill
+	modeCancel                     // Cancelled, see Cancel
+)
+
+func (m teardownMode) String() string {
+	if m == modeCancel {
+		return "cancel"
+	}
+	return "kill"
+}
+
+// actionState describes the progress of a Cascade's actions.
+type actionState int
+
+const (
+	actionsPending actionState = iota // Actions have not run yet
+	actionsRunning                    // Actions are currently being run
+	actionsRan                        // All actions have been run
+	actionsSkipped                    // The Cascade was cancelled so actions will never run
+)
+
+// Executes queued kill actions
 func (c *Cascade) runActions() {
-	c.onceActions.Do(func() {
+	c.runActionList(&c.actions)
+}
+
+// Executes queued cancel actions
+func (c *Cascade) runCancelActions() {
+	c.runActionList(&c.cancelActions)
+}
+
+// Executes queued always actions
+func (c *Cascade) runAlwaysActions() {
+	c.runActionList(&c.alwaysActions)
+}
+
+// Executes the actions of the list
+//
+// The lock is not held while an action is running so that actions can safely register further actions.
+func (c *Cascade) runActionList(l *actionList) {
+	l.once.Do(func() {
+		sequenced := c.isSequenced()
+		parallel := c.isParallel()
+		timeout := c.getActionTimeout()
 		c.muActions.Lock()
-		for _, action := range c.actions {
-			action()
+		if l.state == actionsSkipped { // Abandoned, see WaitOrForce
+			c.muActions.Unlock()
+			return
+		}
+		l.state = actionsRunning
+		finished := true
+		if parallel {
+			finished = c.runActionsInParallel(l, timeout)
+		} else {
+			if sequenced {
+				sort.SliceStable(l.actions, func(i, j int) bool {
+					return l.actions[i].seq < l.actions[j].seq
+				})
+			}
+			for l.next = 0; finished && l.next < len(l.actions); {
+				action := l.actions[l.next]
+				l.next++
+				step := c.stepActions
+				c.muActions.Unlock()
+				if step != nil {
+					<-step
+				}
+				finished = runWithTimeout(action.run, timeout)
+				c.muActions.Lock()
+			}
 		}
+		skipped := len(l.actions) - l.next
+		if !finished {
+			l.actions = l.actions[:l.next] // The remaining actions are dropped
+		}
+		l.state = actionsRan
 		c.muActions.Unlock()
+		if !finished {
+			c.setErrorIfUnset(&ActionTimeoutError{Path: c.Path(), Skipped: skipped, Timeout: timeout})
+		}
 	})
 }
 
+// Runs the function, waiting for up to the provided duration (or without limit if it is 0) for it to return.
+// Returns `false` on a timeout, the function is left running.
+func runWithTimeout(run func(), d time.Duration) bool {
+	if d <= 0 {
+		run()
+		return true
+	}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		run()
+	}()
+	return waitWithTimeout(finished, d)
+}
+
+// Runs the actions of a list concurrently, in batches until no action is left. Returns `false` if a batch took
+// longer than the action timeout (0 waits without limit). MUST be called with muActions held, it is held again
+// when this returns.
+func (c *Cascade) runActionsInParallel(l *actionList, timeout time.Duration) bool {
+	for l.next < len(l.actions) {
+		batch := append([]killAction(nil), l.actions[l.next:]...)
+		l.next = len(l.actions)
+		c.muActions.Unlock()
+		finished := runWithTimeout(func() {
+			wg := sync.WaitGroup{}
+			for _, action := range batch {
+				wg.Add(1)
+				go func(run func()) {
+					defer wg.Done()
+					run()
+				}(action.run)
+			}
+			wg.Wait()
+		}, timeout)
+		c.muActions.Lock()
+		if !finished {
+			return false
+		}
+	}
+	return true
+}
+
+// Adds an action to the end of the list, or runs it right away if the actions of the list have been run
+func (c *Cascade) addAction(l *actionList, action func(), seq int64) {
+	c.muActions.Lock()
+	if l.state == actionsRan {
+		c.muActions.Unlock()
+		action()
+		return
+	}
+	l.actions = append(l.actions, killAction{run: action, seq: seq})
+	c.muActions.Unlock()
+}
+
+// Adds an action to the front of the list, or runs it right away if the actions of the list have been run
+func (c *Cascade) addFirstAction(l *actionList, action func(), seq int64) {
+	c.muActions.Lock()
+	if l.state == actionsRan {
+		c.muActions.Unlock()
+		action()
+		return
+	}
+	// While the actions are running, the "first" action is the next one to run, otherwise next is 0.
+	l.actions = append(l.actions, killAction{})
+	copy(l.actions[l.next+1:], l.actions[l.next:])
+	l.actions[l.next] = killAction{run: action, seq: seq}
+	c.muActions.Unlock()
+}
+
+// Runs the pre-stop hooks (or records them as skipped), newly added hooks are run until none are left
+func (c *Cascade) runPreStop(run bool) {
+	c.muActions.Lock()
+	if !run {
+		c.preState = actionsSkipped
+		c.muActions.Unlock()
+		return
+	}
+	c.preState = actionsRunning
+	for i := 0; i < len(c.preStop); i++ {
+		hook := c.preStop[i]
+		c.muActions.Unlock()
+		hook()
+		c.muActions.Lock()
+	}
+	c.preState = actionsRan
+	c.muActions.Unlock()
+}
+
+// Runs the critical actions, newly added critical actions are run until none are left
+func (c *Cascade) runCritical() {
+	c.muActions.Lock()
+	c.critState = actionsRunning
+	for i := 0; i < len(c.critical); i++ {
+		action := c.critical[i]
+		c.muActions.Unlock()
+		c.runSafely(action)
+		c.muActions.Lock()
+	}
+	c.critState = actionsRan
+	c.muActions.Unlock()
+}
+
+// Runs an action, recovering from a panic by setting it as the error of the Cascade (if none is set)
+func (c *Cascade) runSafely(action func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.setErrorIfUnset(&PanicError{Value: r, Stack: debug.Stack()})
+			c.logEvent("recovered panic in critical action: %v", r)
+		}
+	}()
+	action()
+}
+
+// Records the queued cancel actions as skipped
+func (c *Cascade) skipCancelActions() {
+	c.muActions.Lock()
+	c.cancelActions.state = actionsSkipped
+	c.muActions.Unlock()
+}
+
+// Records the queued kill actions as skipped
+func (c *Cascade) skipActions() {
+	c.muActions.Lock()
+	c.actions.state = actionsSkipped
+	c.skipped = len(c.actions.actions)
+	c.muActions.Unlock()
+	if c.skipped > 0 && c.isStrict() {
+		c.logf("cascade: %s was cancelled with %d kill action(s) registered, they were skipped", c.Path(), c.skipped)
+	}
+}
+
 func (c *Cascade) removeChild(child *Cascade) {
+	errs := child.CollectErrors() // Collected before locking, the child's lock has the same rank
 	c.muChildren.Lock()
-	delete(c.children, child)
+	if _, ok := c.children[child]; ok {
+		delete(c.children, child)
+		if len(errs) > 0 {
+			c.childErrs = append(c.childErrs, collectedErrors{id: child.id, errs: errs})
+		}
+		c.notifyChildEvent(ChildRemoved, child)
+	}
 	c.muChildren.Unlock()
 }
 
 func (c *Cascade) cancelTrackedContexts() {
+	cause := c.explicitError()
 	c.muCtx.Lock()
 	for _, tracked := range c.trackedCtx {
-		tracked.cancel()
+		tracked.cancel(cause)
 	}
 	c.trackedCtx = nil
+	for _, cancel := range c.ctxCancels {
+		cancel()
+	}
+	c.ctxCancels = nil
 	c.muCtx.Unlock()
 }
 
-func (c *Cascade) closeAndClean(actions bool) {
+// Waits for the Cascade to become dead, reporting to the Logger if that takes longer than the watchdog duration
+// or the leak detection duration (panicking as well if leak detection is set to)
+func (c *Cascade) waitWithWatchdog() {
+	if d := c.watchdogDuration(); d > 0 {
+		timer := time.AfterFunc(d, func() {
+			c.logf("%s", c.stuckReport(d))
+		})
+		defer timer.Stop()
+	}
+	if d, panics := c.leakDetection(); d > 0 {
+		timer := time.AfterFunc(d, func() {
+			msg := c.stuckReport(d)
+			if sites := c.markSites(); sites != "" {
+				msg += ", outstanding marks: " + sites
+			}
+			c.logf("%s", msg)
+			if panics {
+				leakPanic(msg)
+			}
+		})
+		defer timer.Stop()
+	}
+	c.Wait()
+}
+
+// Describes a Cascade that has been waiting for its tracked goroutines for the provided duration
+func (c *Cascade) stuckReport(d time.Duration) string {
+	return fmt.Sprintf("cascade: %s is still waiting for %d tracked goroutine(s) to exit after %s", c.Path(),
+		c.TrackedCount(), d)
+}
+
+// Called when leak detection is set to panic, replaced by tests
+var leakPanic = func(msg string) {
+	panic(msg)
+}
+
+func (c *Cascade) closeAndClean(mode teardownMode) {
 	c.muChildren.Lock()
+	orphans.Add(int64(len(c.children))) // Added after the teardown reached them, ChildCascade kills them itself
 	c.children = nil
+	c.childWait = nil // Waiters will be released by done
 	c.muChildren.Unlock()
-	c.onceDying.Do(func() {
-		close(c.dying) // This Cascade is dying! bye bye
-	})
-	c.muTracked.RLock()
-	if c.tracked == 0 {
-		c.onceDead.Do(func() {
-			close(c.dead)
-		})
-		c.muTracked.RUnlock()
+	c.closeDying() // This Cascade is dying! bye bye
+	c.notifyState(StateDying)
+	c.muTracked.Lock()
+	c.condTracked.Broadcast() // Release any marks waiting on a quiesced Cascade
+	c.muTracked.Unlock()
+	if c.tracked.Load() == 0 && c.watchers.Load() == 0 {
+		c.closeDead()
 	} else {
-		c.muTracked.RUnlock()
-		c.Wait()
+		c.waitWithWatchdog()
 	}
-	if actions {
+	c.notifyState(StateDead)
+	c.logEvent("is dead")
+	c.runCritical()
+	c.logEvent("running %s actions", mode)
+	if mode == modeKill {
 		c.runActions()
+		c.skipCancelActions()
+	} else {
+		c.skipActions()
+		c.runCancelActions()
 	}
+	c.runAlwaysActions()
 	c.cancelTrackedContexts()
-	if c.parent != nil {
-		c.parent.removeChild(c)
+	if parent := c.Parent(); parent != nil {
+		parent.removeChild(c)
 	}
-	c.onceDone.Do(func() {
-		close(c.done) // This Cascade is done! bye bye
-	})
+	c.muDead.Lock()
+	c.tdDuration = time.Since(c.tdStart)
+	c.muDead.Unlock()
+	c.notifyState(StateDone)
+	c.logEvent("is done")
+	close(c.done) // This Cascade is done! bye bye (teardown only ever runs once)
 }
 
 // Wrap wraps a function that takes a Cascade as an argument and turns it into a tracked function.
@@ -151,9 +503,14 @@ func (c *Cascade) closeAndClean(actions bool) {
 // The provided function MUST implement an exit condition using the provided Cascade.
 //
 // For an example of a suitable function, see the example for the `Go` function.
+//
+// If the Cascade is draining (see `Drain`), the function is not called.
 func (c *Cascade) Wrap(f func(*Cascade)) {
-	c.Mark()
+	if !c.Mark() {
+		return
+	}
 	defer c.UnMark()
+	defer c.handlePanic()
 	f(c)
 }
 
@@ -165,8 +522,11 @@ func (c *Cascade) Wrap(f func(*Cascade)) {
 //
 // Warning: The only way to exit the function is to kill or cancel the Cascade.
 func (c *Cascade) WrapInLoop(f func()) {
-	c.Mark()
+	if !c.Mark() {
+		return
+	}
 	defer c.UnMark()
+	defer c.handlePanic()
 	for {
 		select {
 		case <-c.Dying():
@@ -185,8 +545,11 @@ func (c *Cascade) WrapInLoop(f func()) {
 // The provided function MUST not block, it will continue getting called until the Cascade is killed or cancelled
 // or the provided function returns `false`
 func (c *Cascade) WrapInLoopWithBool(f func() bool) {
-	c.Mark()
+	if !c.Mark() {
+		return
+	}
 	defer c.UnMark()
+	defer c.handlePanic()
 	var fDone bool
 	for {
 		select {
@@ -207,6 +570,9 @@ func (c *Cascade) WrapInLoopWithBool(f func() bool) {
 //
 // The provided function MUST implement an exit condition using the provided Cascade.
 //
+// If the current Cascade is already dead, the returned child is dead as well (see `ChildCascade`) and the
+// function is not run at all.
+//
 // Example Function:
 //  func(c *Cascade) {
 //  	// Do Something
@@ -214,7 +580,26 @@ func (c *Cascade) WrapInLoopWithBool(f func() bool) {
 //  }
 func (c *Cascade) Go(f func(*Cascade)) *Cascade {
 	child := c.ChildCascade()
-	go child.Wrap(f)
+	child.launch(func() { child.Wrap(f) })
+	return child
+}
+
+// GoWithError runs the provided function as a tracked goroutine (just like `Go`). If the function returns
+// an error, the current Cascade is killed with that error (see `KillWithError`), tearing down every other
+// child along with it. Only the first error is kept, it can be retrieved with `Error` once the current
+// Cascade is dead.
+//
+// The returned Cascade is a child of the current Cascade that is tracking the provided function.
+func (c *Cascade) GoWithError(f func(*Cascade) error) *Cascade {
+	child := c.ChildCascade()
+	child.launch(func() {
+		child.Wrap(func(cc *Cascade) {
+			if err := f(cc); err != nil {
+				// Killing the current Cascade waits for this goroutine, so it can't be done synchronously
+				go c.KillWithError(err)
+			}
+		})
+	})
 	return child
 }
 
@@ -227,7 +612,7 @@ func (c *Cascade) Go(f func(*Cascade)) *Cascade {
 // Warning: The only way to exit the function is to kill or cancel the Cascade.
 func (c *Cascade) GoInLoop(f func()) *Cascade {
 	child := c.ChildCascade()
-	go child.WrapInLoop(f)
+	child.launch(func() { child.WrapInLoop(f) })
 	return child
 }
 
@@ -240,10 +625,42 @@ func (c *Cascade) GoInLoop(f func()) *Cascade {
 // The returned Cascade is a child of the current Cascade that is tracking the provided function.
 func (c *Cascade) GoInLoopWithBool(f func() bool) *Cascade {
 	child := c.ChildCascade()
-	go child.WrapInLoopWithBool(f)
+	child.launch(func() { child.WrapInLoopWithBool(f) })
 	return child
 }
 
+// Scope creates a child Cascade, calls the provided function with it and kills the child (running its actions)
+// once the function returns. Everything started on the child, for example with `Go`, is guaranteed to have
+// exited by the time Scope returns.
+//
+// The returned error joins the error returned by the function with the error set on the child, if any (see
+// `errors.Join`).
+//
+// This is NOT a goroutine and will block until the provided function returns and the child is done.
+func (c *Cascade) Scope(f func(*Cascade) error) error {
+	child := c.ChildCascade()
+	defer child.Kill() // Still tear down the scope if the function panics
+	err := f(child)
+	child.Kill()
+	return errors.Join(err, child.explicitError())
+}
+
+// Runs the provided function in a new goroutine. The goroutine is tracked from before it starts so that a
+// Cascade killed right away still waits for it. Returns `false` without running the function (or marking the
+// Cascade) if the Cascade was rejected by a draining parent, is draining itself or is already dead, for
+// example because it was created on a dead parent.
+func (c *Cascade) launch(run func()) bool {
+	c.launched.Store(true)
+	if c.rejected || c.IsDead() || !c.Mark() {
+		return false
+	}
+	go func() {
+		defer c.UnMark()
+		run()
+	}()
+	return true
+}
+
 // Hold blocks until the Cascade is considered dying.
 //
 // This should be what goroutines use to determine when to exit.
@@ -259,6 +676,94 @@ func (c *Cascade) Wait() {
 	<-c.dead
 }
 
+// WaitOrForce blocks until the Cascade is considered dead (just like `Wait`) or until the force channel is
+// signalled, whichever comes first. Returns `true` if the Cascade died on its own.
+//
+// When forced, the Cascade and all of its descendants are cancelled (if they were not already killed or
+// cancelled) and immediately considered dead without waiting any longer for their tracked goroutines.
+// Actions that have not started running yet are skipped. This is meant for "press Ctrl-C again to force
+// quit" and can safely be used while a `Kill` is in progress.
+//
+// Warning: Goroutines that are stuck when the Cascade is forced are abandoned, they keep running after
+// the Cascade is done.
+func (c *Cascade) WaitOrForce(force <-chan struct{}) bool {
+	select {
+	case <-c.dead:
+		return true
+	case <-force:
+		c.abandon()
+		return false
+	}
+}
+
+// Cancels the Cascade and its descendants and forces them to be dead, skipping any pending actions
+func (c *Cascade) abandon() {
+	for _, child := range c.childSnapshot() {
+		child.abandon()
+	}
+	c.muActions.Lock()
+	if c.actions.state == actionsPending {
+		c.actions.state = actionsSkipped
+		c.skipped = len(c.actions.actions)
+	}
+	if c.cancelActions.state == actionsPending {
+		c.cancelActions.state = actionsSkipped
+	}
+	c.muActions.Unlock()
+	if c.markDead(modeCancel) {
+		go c.teardown(modeCancel)
+	}
+	c.closeDying()
+	c.closeDead()
+}
+
+// HoldWithTimeout blocks until the Cascade is considered dying (just like `Hold`) or until the provided
+// duration has passed. Returns `true` if the Cascade started dying in time.
+func (c *Cascade) HoldWithTimeout(d time.Duration) bool {
+	return waitWithTimeout(c.dying, d)
+}
+
+// HoldWithContext blocks until the Cascade is considered dying (just like `Hold`) or until the provided Context
+// is done. Returns `nil` if the Cascade is dying, even if the Context is done as well, or the error of the
+// Context (see `context.Context.Err`) if only the Context is done.
+func (c *Cascade) HoldWithContext(ctx context.Context) error {
+	select {
+	case <-c.dying:
+		return nil
+	case <-ctx.Done():
+		select {
+		case <-c.dying: // Both were ready, dying wins
+			return nil
+		default:
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitWithTimeout blocks until the Cascade is considered dead (just like `Wait`) or until the provided
+// duration has passed. Returns `true` if the Cascade became dead in time.
+func (c *Cascade) WaitWithTimeout(d time.Duration) bool {
+	return waitWithTimeout(c.dead, d)
+}
+
+// WaitDoneWithTimeout blocks until the Cascade is completely done (just like `WaitDone`) or until the
+// provided duration has passed. Returns `true` if the Cascade was done in time.
+func (c *Cascade) WaitDoneWithTimeout(d time.Duration) bool {
+	return waitWithTimeout(c.done, d)
+}
+
+// Waits for the channel to be closed, returns `false` if that did not happen within the provided duration
+func waitWithTimeout(ch <-chan struct{}, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ch:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
 // WaitDone blocks until the Cascade is completely done.
 //
 // This can be used as a signal to indicate when all goroutines have exited and
@@ -267,18 +772,94 @@ func (c *Cascade) WaitDone() {
 	<-c.done
 }
 
+// WaitDoneDuration blocks until the Cascade is completely done (just like `WaitDone`) and returns how long
+// the teardown took (see `TeardownDuration`).
+func (c *Cascade) WaitDoneDuration() time.Duration {
+	<-c.done
+	return c.TeardownDuration()
+}
+
+// TeardownDuration returns how long the Cascade took to go from being killed or cancelled to being
+// completely done, including waiting for children, tracked goroutines and actions.
+//
+// While the teardown is still in progress the time elapsed so far is returned, and `0` is returned if
+// the Cascade has not started tearing down yet.
+func (c *Cascade) TeardownDuration() time.Duration {
+	c.muDead.RLock()
+	defer c.muDead.RUnlock()
+	if c.tdStart.IsZero() {
+		return 0
+	}
+	select {
+	case <-c.done:
+		return c.tdDuration
+	default:
+		return time.Since(c.tdStart)
+	}
+}
+
 // Dying provides a channel that will close once the Cascade is considered dying.
 //
 // This should be what goroutines use to determine when to exit.
-func (c *Cascade) Dying() <-chan interface{} {
+func (c *Cascade) Dying() <-chan struct{} {
 	return c.dying
 }
 
+// ShouldExit returns `true` once the Cascade is dying (see `Dying`). It is the non-blocking version of `Hold`
+// and makes the same check that `WrapInLoop` and its variants make before every call.
+//
+// This differs from `IsDead`: a Cascade is dead as soon as it is killed or cancelled, but only starts dying
+// once all of its children are done. Tracked goroutines should use ShouldExit (or `Dying`) so that they keep
+// running for as long as the children that may depend on them.
+func (c *Cascade) ShouldExit() bool {
+	select {
+	case <-c.dying:
+		return true
+	default:
+		return false
+	}
+}
+
+// DyingReason provides a channel that receives the reason the Cascade is dying once it is considered dying:
+// the error set on the Cascade at that point (see `Error`), or `nil` if it is being killed or cancelled
+// without an error. This allows a goroutine to adjust its cleanup depending on why it has to exit.
+//
+// Every call returns a new channel that receives a single value and is then closed, so the reason should
+// only be read once. `Dying` is unaffected and remains the preferred exit condition.
+func (c *Cascade) DyingReason() <-chan error {
+	reason := make(chan error, 1)
+	c.muDead.Lock()
+	defer c.muDead.Unlock()
+	select {
+	case <-c.dying:
+		reason <- c.explicitError()
+		close(reason)
+	default:
+		c.reasonWait = append(c.reasonWait, reason)
+	}
+	return reason
+}
+
+// Closes the dying channel and hands the reason to the callers of DyingReason
+func (c *Cascade) closeDying() {
+	c.onceDying.Do(func() {
+		c.muDead.Lock()
+		defer c.muDead.Unlock()
+		close(c.dying)
+		err := c.explicitError()
+		for _, reason := range c.reasonWait {
+			reason <- err
+			close(reason)
+		}
+		c.reasonWait = nil
+	})
+}
+
 // Dead provides a channel that will close once the Cascade is considered dead.
 //
 // This can be used as a signal to indicate when all goroutines have exited.
 // However, actions may not have run yet
-func (c *Cascade) Dead() <-chan interface{} {
+func (c *Cascade) Dead() <-chan struct{} {
 	return c.dead
 }
 
@@ -286,15 +867,73 @@ func (c *Cascade) Dead() <-chan interface{} {
 //
 // This can be used as a signal to indicate when all goroutines have exited and
 // all actions have been completed.
-func (c *Cascade) Done() <-chan interface{} {
+//
+// Done is also part of the `context.Context` implementation of the Cascade, see `Err`.
+func (c *Cascade) Done() <-chan struct{} {
 	return c.done
 }
 
+// Deadline returns the deadline of the Context the Cascade is linked to (see `WithContext`), if any.
+// Part of the `context.Context` implementation of the Cascade, see `Err`.
+func (c *Cascade) Deadline() (time.Time, bool) {
+	c.muCtx.Lock()
+	ctx := c.ctx
+	c.muCtx.Unlock()
+	if ctx == nil {
+		return time.Time{}, false
+	}
+	return ctx.Deadline()
+}
+
+// Err returns `nil` until the Cascade is completely done (see `Done`), afterwards it returns the error set
+// on the Cascade (see `Error`) or `context.Canceled` (rather than `ErrKilled` or `ErrCancelled`) if no error
+// was set.
+//
+// Together with `Deadline`, `Done` and `Value`, this makes the Cascade a `context.Context` that can be passed
+// directly to anything that expects one, and that is cancelled once the Cascade is done.
+//
+// Warning: Since the Cascade as a Context is only cancelled once it is completely done, it MUST NOT be used
+// as the Context of work that the Cascade itself is tracking, that would block the Cascade forever. Use a
+// Context returned by `Context` for that instead.
+func (c *Cascade) Err() error {
+	select {
+	case <-c.done:
+	default:
+		return nil
+	}
+	if err := c.explicitError(); err != nil {
+		return err
+	}
+	return context.Canceled
+}
+
+// Value returns the value associated with the key on the Cascade. Values stored with `SetValue` come first,
+// then the values of the Context the Cascade is linked to (see `WithContext`). A Cascade without a linked
+// Context looks the key up on its parent instead, so values stored or linked anywhere above the Cascade in
+// the tree are visible to it. `nil` is returned if there is no such value. Part of the `context.Context`
+// implementation of the Cascade, see `Err`.
+func (c *Cascade) Value(key interface{}) interface{} {
+	c.muMeta.RLock()
+	val, ok := c.values[key]
+	c.muMeta.RUnlock()
+	if ok {
+		return val
+	}
+	c.muCtx.Lock()
+	ctx := c.ctx
+	c.muCtx.Unlock()
+	if ctx != nil {
+		return ctx.Value(key)
+	}
+	if parent := c.Parent(); parent != nil {
+		return parent.Value(key)
+	}
+	return nil
+}
+
 // IsDead returns `true` if the Cascade has been cancelled or killed.
 func (c *Cascade) IsDead() bool {
-	c.muDead.RLock()
-	defer c.muDead.RUnlock()
-	return c.isDead
+	return c.isDead.Load()
 }
 
 // Alive returns `true` if the Cascade has not been cancelled or killed.
@@ -307,24 +946,187 @@ func (c *Cascade) Alive() bool {
 //
 // Note: This function blocks until all children and the specified Cascade have finished exiting.
 func (c *Cascade) Kill() {
+	if c.markDead(modeKill) {
+		c.teardown(modeKill)
+	}
+}
+
+// KillWithTimeout starts killing the Cascade (just like `Kill`) and waits up to the provided duration for it
+// to become dead (see `Dead`). If that takes longer, a `*KillTimeoutError` reporting the number of goroutines
+// that are still tracked is returned.
+//
+// The kill is not abandoned on a timeout: the Cascade stays dying, goroutines that exit late are still untracked
+// and the actions are still run once it is dead. Only the caller regains control.
+func (c *Cascade) KillWithTimeout(d time.Duration) error {
+	go c.Kill()
+	if waitWithTimeout(c.dead, d) {
+		return nil
+	}
+	return &KillTimeoutError{Path: c.Path(), Tracked: c.TrackedCount(), Timeout: d}
+}
+
+// Flags the Cascade as dead, returns `false` if it was already dead
+func (c *Cascade) markDead(mode teardownMode) bool {
 	c.muDead.Lock()
-	if !c.isDead {
-		c.isDead = true
-		c.muDead.Unlock()
-		wg := sync.WaitGroup{}
-		c.muChildren.Lock()
-		for child := range c.children {
-			wg.Add(1)
-			go func(ch *Cascade) {
+	defer c.muDead.Unlock()
+	if !c.isDead.CompareAndSwap(false, true) {
+		return false
+	}
+	c.deathMode = mode
+	c.Root().countDeath(mode)
+	return true
+}
+
+// Returns `true` if the Cascade has been cancelled rather than killed
+func (c *Cascade) isCancelled() bool {
+	if !c.isDead.Load() {
+		return false
+	}
+	c.muDead.RLock()
+	defer c.muDead.RUnlock()
+	return c.deathMode == modeCancel
+}
+
+// Kills or cancels all children and waits for them to exit before closing out the Cascade itself
+func (c *Cascade) teardown(mode teardownMode) {
+	c.muDead.Lock()
+	c.tdStart = time.Now()
+	c.muDead.Unlock()
+	c.logEvent("%s initiated", mode)
+	c.runPreStop(mode == modeKill || c.preStopOnCancel())
+	c.stopChildren(mode, c.childSnapshot())
+	c.closeAndClean(mode)
+}
+
+// Kills or cancels the provided children concurrently and waits for all of them to be done
+func (c *Cascade) stopChildren(mode teardownMode, children []*Cascade) {
+	slow := c.slowChildThreshold()
+	wg := sync.WaitGroup{}
+	for _, child := range children {
+		wg.Add(1)
+		go func(ch *Cascade) {
+			if slow > 0 {
+				timer := time.AfterFunc(slow, func() {
+					c.logf("cascade: child %s is still tearing down after %s", ch.Path(), slow)
+				})
+				defer timer.Stop()
+			}
+			if mode == modeKill {
 				ch.Kill()
-				wg.Done()
-			}(child)
+			} else {
+				ch.Cancel()
+			}
+			c.logEvent("child %s stopped (%s)", ch.Path(), mode)
+			wg.Done()
+		}(child)
+	}
+	wg.Wait()
+	for _, child := range children {
+		c.removeChild(child) // Usually already removed by the child itself
+	}
+}
+
+// Sets the error on the Cascade unless an error has already been set
+func (c *Cascade) setErrorIfUnset(err error) {
+	c.muErr.Lock()
+	if c.err == nil {
+		c.err = err
+	}
+	c.muErr.Unlock()
+}
+
+// Close kills the Cascade (just like `Kill`) and returns the error set on the Cascade, if any (see `Error`).
+// A successful close returns `nil` rather than `ErrKilled`. This allows a Cascade to be used anywhere an
+// `io.Closer` is expected.
+//
+// Note: Just like `Kill`, Close blocks until all children and the current Cascade have finished exiting,
+// including running all actions. Unlike most `io.Closer` implementations this can take a long time, unless a
+// kill timeout is set (see `SetKillTimeout`): Close then returns a `*KillTimeoutError` if the Cascade is not
+// dead in time, without waiting for the actions.
+func (c *Cascade) Close() error {
+	if d := c.getKillTimeout(); d > 0 {
+		if err := c.KillWithTimeout(d); err != nil {
+			return err
 		}
-		c.muChildren.Unlock()
-		wg.Wait()
-		c.closeAndClean(true)
-	} else {
-		c.muDead.Unlock()
+		c.WaitDone()
+		return c.explicitError()
+	}
+	c.Kill()
+	return c.explicitError()
+}
+
+// KillWhen will kill the Cascade once the other Cascade is dead (see `Dead`) without making it a child of
+// the other Cascade. This can be used to tie Cascades in different parts of a tree together.
+//
+// The watching goroutine is waited for by `Wait` (it is not counted by `TrackedCount`) and exits as soon as
+// this Cascade starts dying, so it never outlives either Cascade.
+func (c *Cascade) KillWhen(other *Cascade) {
+	c.markWatcher()
+	go func() {
+		select {
+		case <-c.Dying():
+			c.unmarkWatcher()
+		case <-other.Dead():
+			c.unmarkWatcher() // Must not be counted while waiting on the kill
+			c.Kill()
+		}
+	}()
+}
+
+// Link makes the provided Cascades share their fate: as soon as any one of them starts dying (see `Dying`),
+// whether it was killed, cancelled or torn down by its parent, all of the others are killed.
+//
+// This is a fate-sharing group that is separate from the parent-child hierarchy: none of the Cascades becomes
+// a child of another and the group has no Cascade of its own. It is usually used to tie independent
+// `RootCascade`s together. Cascades that are dead already kill the others right away.
+//
+// Each Cascade watches itself with a goroutine that it waits for until it starts dying, the kills of the others
+// are started in the background so that Link never blocks. Kills that reach a Cascade that is already dying
+// do nothing, so the kills coming back from the other watchers end there.
+func Link(cascades ...*Cascade) {
+	group := append([]*Cascade(nil), cascades...)
+	for _, c := range group {
+		c.markWatcher()
+		go func(c *Cascade) {
+			<-c.Dying()
+			c.unmarkWatcher() // Must not be counted while the others are killed, they may come back to kill this one
+			for _, other := range group {
+				if other != c {
+					go other.Kill()
+				}
+			}
+		}(c)
+	}
+}
+
+// KillAfter will kill the Cascade (just like `Kill`) once the provided duration has passed. The returned timer
+// can be stopped to prevent the kill.
+//
+// The timer does not use a goroutine while it is waiting, and if the Cascade is already dead when it fires
+// nothing happens.
+func (c *Cascade) KillAfter(d time.Duration) *time.Timer {
+	return time.AfterFunc(d, c.Kill)
+}
+
+// CancelAfter will cancel the Cascade (just like `Cancel`) once the provided duration has passed. The returned
+// timer can be stopped to prevent the cancel. See `KillAfter`.
+func (c *Cascade) CancelAfter(d time.Duration) *time.Timer {
+	return time.AfterFunc(d, c.Cancel)
+}
+
+// KillContext will kill the Cascade just like `Kill` but will stop waiting for the kill to complete
+// if the provided Context is cancelled first.
+//
+// Returns `nil` once the Cascade is completely done or the Context's error if the Context was
+// cancelled before that. Cancelling the Context only stops the wait, the kill itself continues in
+// the background and can still be waited on with `WaitDone`.
+func (c *Cascade) KillContext(ctx context.Context) error {
+	go c.Kill()
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
@@ -335,18 +1137,72 @@ func (c *Cascade) Kill() {
 //
 // This function blocks until all children and the current Cascade have finished exiting.
 //
-// An error will be returned if an error has already been set on the current Cascade.
+// If an error has already been set on the current Cascade, that error is kept and the Cascade is still killed.
+// An `*AlreadySetError` holding the existing error and the dropped one is returned once the kill is complete.
+// With error joining enabled (see `SetErrorJoining`) the provided error is joined onto the existing one instead.
 func (c *Cascade) KillWithError(err error) error {
+	setErr := c.setOrJoinError(err)
+	c.Kill()
+	return setErr
+}
+
+// Sets the error on the Cascade, or joins it onto the existing error if error joining is enabled. Returns an
+// `*AlreadySetError` if the error was dropped.
+func (c *Cascade) setOrJoinError(err error) error {
+	joining := c.isErrorJoining()
 	c.muErr.Lock()
+	defer c.muErr.Unlock()
+	switch {
+	case c.err == nil:
+		c.err = err
+	case joining:
+		c.err = errors.Join(c.err, err)
+	default:
+		return &AlreadySetError{Existing: c.err, Dropped: err}
+	}
+	return nil
+}
 
-	if c.err != nil {
-		c.muErr.Unlock()
-		return errors.New("cascade: error already set")
+// AddError adds an error to the Cascade without killing it. If an error has already been set, the provided error
+// is joined onto it with `errors.Join`, so `Error` returns every error that was added and `errors.Is` matches any
+// of them. Passing `nil` does nothing.
+func (c *Cascade) AddError(err error) {
+	if err == nil {
+		return
+	}
+	c.muErr.Lock()
+	if c.err == nil {
+		c.err = err
+	} else {
+		c.err = errors.Join(c.err, err)
 	}
-	c.err = err
 	c.muErr.Unlock()
+}
+
+// KillWithCause will kill the Cascade and any children (just like the `KillWithError` function) and
+// guarantees that every Context returned by the Cascade is cancelled with the provided error as its cause
+// (see `context.Cause`), so the error reported by `Error` always matches the cause seen through Contexts.
+//
+// Notes:
+//
+// This function blocks until all children and the current Cascade have finished exiting.
+//
+// The error is set (or joined, see `SetErrorJoining`) just like with `KillWithError`, and an `*AlreadySetError`
+// is returned if it had to be dropped. The Cascade is still killed in that case and its Contexts are cancelled
+// with the error that was already set, so the two surfaces keep matching.
+//
+// `ErrAlreadyDead` is returned, and the error is not set, if the Cascade has already been killed or cancelled
+// since its Contexts may already have been cancelled with a different cause.
+func (c *Cascade) KillWithCause(err error) error {
+	c.muDead.Lock() // Held while setting the error so that a concurrent kill cannot start before it is set
+	if c.isDead.Load() {
+		c.muDead.Unlock()
+		return ErrAlreadyDead
+	}
+	setErr := c.setOrJoinError(err)
+	c.muDead.Unlock()
 	c.Kill()
-	return nil
+	return setErr
 }
 
 // Cancel will kill the Cascade and any children (just like the `Kill` function) but will not run
@@ -354,24 +1210,8 @@ func (c *Cascade) KillWithError(err error) error {
 //
 // Note: This function blocks until all children and the specified Cascade have finished exiting.
 func (c *Cascade) Cancel() {
-	c.muDead.Lock()
-	if !c.isDead {
-		c.isDead = true
-		c.muDead.Unlock()
-		wg := sync.WaitGroup{}
-		c.muChildren.Lock()
-		for child := range c.children {
-			wg.Add(1)
-			go func(ch *Cascade) {
-				ch.Cancel()
-				wg.Done()
-			}(child)
-		}
-		c.muChildren.Unlock()
-		wg.Wait()
-		c.closeAndClean(false)
-	} else {
-		c.muDead.Unlock()
+	if c.markDead(modeCancel) {
+		c.teardown(modeCancel)
 	}
 }
 
@@ -382,15 +1222,12 @@ func (c *Cascade) Cancel() {
 //
 // This function blocks until all children and the current Cascade have finished exiting.
 //
-// An error will be returned if an error has already been set on the current Cascade.
+// An `*AlreadySetError` holding the existing error will be returned, and the Cascade will not be cancelled, if
+// an error has already been set on the current Cascade, unless error joining is enabled (see `SetErrorJoining`).
 func (c *Cascade) CancelWithError(err error) error {
-	c.muErr.Lock()
-	if c.err != nil {
-		c.muErr.Unlock()
-		return errors.New("cascade: error already set")
+	if setErr := c.setOrJoinError(err); setErr != nil {
+		return setErr
 	}
-	c.err = err
-	c.muErr.Unlock()
 	c.Cancel()
 	return nil
 }
@@ -400,8 +1237,8 @@ func (c *Cascade) CancelWithError(err error) error {
 //
 // Note: This function blocks until ALL Cascades have been killed and finished exiting.
 func (c *Cascade) KillAll() {
-	if c.parent != nil {
-		c.parent.KillAll()
+	if parent := c.Parent(); parent != nil {
+		parent.KillAll()
 	} else {
 		// We found the root!
 		c.Kill()
@@ -415,10 +1252,10 @@ func (c *Cascade) KillAll() {
 //
 // This function blocks until ALL Cascades have been killed and finished exiting.
 //
-// An error will be returned if an error has already been set on the current Cascade.
+// If an error has already been set on the `RootCascade`, that error is kept (see `KillWithError`).
 func (c *Cascade) KillAllWithError(err error) {
-	if c.parent != nil {
-		c.parent.KillAllWithError(err)
+	if parent := c.Parent(); parent != nil {
+		parent.KillAllWithError(err)
 	} else {
 		// We found the root!
 		c.KillWithError(err)
@@ -430,8 +1267,8 @@ func (c *Cascade) KillAllWithError(err error) {
 //
 // Note: This function blocks until ALL Cascades have been cancelled and finished exiting.
 func (c *Cascade) CancelAll() {
-	if c.parent != nil {
-		c.parent.CancelAll()
+	if parent := c.Parent(); parent != nil {
+		parent.CancelAll()
 	} else {
 		// We found the root!
 		c.Cancel()
@@ -447,8 +1284,8 @@ func (c *Cascade) CancelAll() {
 //
 // An error will be returned if an error has already been set on the current Cascade.
 func (c *Cascade) CancelAllWithError(err error) {
-	if c.parent != nil {
-		c.parent.CancelAllWithError(err)
+	if parent := c.Parent(); parent != nil {
+		parent.CancelAllWithError(err)
 	} else {
 		// We found the root!
 		c.CancelWithError(err)
@@ -459,36 +1296,284 @@ func (c *Cascade) CancelAllWithError(err error) {
 //
 // Functions are added in a FIFO order and will be executed in order.
 //
+// Actions can be added at any point in the lifecycle of the Cascade:
+// actions added while the Cascade is dying, or by another action while actions are being run, will still
+// be run in order. Actions added after all actions have been run are run immediately by the caller.
+//
+// When actions are added concurrently from several goroutines, the order between them is unspecified unless
+// sequenced actions are enabled (see `SetSequencedActions`).
+//
+// The returned ActionID can be used to unregister the action with `RemoveAction`.
+//
 // Note: These actions will NOT be run if the Cascade is cancelled instead of killed.
-func (c *Cascade) DoOnKill(action func()) {
-	c.muActions.Lock()
-	c.actions = append(c.actions, action)
-	c.muActions.Unlock()
+func (c *Cascade) DoOnKill(action func()) ActionID {
+	seq := c.lastSeq.Add(1)
+	c.addAction(&c.actions, action, seq)
+	return ActionID(seq)
 }
 
 // DoFirstOnKill adds a function to the list of actions that should be performed when the Cascade is killed.
 //
 // Functions are added in a LIFO order and will be executed in order.
 //
+// Actions added while actions are being run (for example, by another action) are run next.
+// Actions added after all actions have been run are run immediately by the caller. See `DoOnKill`.
+//
+// The returned ActionID can be used to unregister the action with `RemoveAction`.
+//
 // Note: These actions will NOT be run if the Cascade is cancelled instead of killed.
-func (c *Cascade) DoFirstOnKill(action func()) {
+func (c *Cascade) DoFirstOnKill(action func()) ActionID {
+	seq := c.firstSeq.Add(-1)
+	c.addFirstAction(&c.actions, action, seq)
+	return ActionID(seq)
+}
+
+// DoOnKillOnce adds a function to the list of actions that should be performed when the Cascade is killed
+// (just like `DoOnKill`), unless an action has already been added with the same key. Returns `true` if the
+// action was added.
+//
+// Keys are never forgotten: once a key has been used, later calls with that key are ignored even if the action
+// has been run or removed (see `RemoveAction`). This makes it safe to register the same cleanup from a loop.
+func (c *Cascade) DoOnKillOnce(key string, action func()) bool {
+	c.muActions.Lock()
+	if c.onceKeys[key] {
+		c.muActions.Unlock()
+		return false
+	}
+	if c.onceKeys == nil {
+		c.onceKeys = make(map[string]bool)
+	}
+	c.onceKeys[key] = true
+	c.muActions.Unlock()
+	c.DoOnKill(action)
+	return true
+}
+
+// RemoveAction unregisters an action that was added with `DoOnKill` or `DoFirstOnKill` so that it is not run
+// when the Cascade is killed. Returns `true` if the action was removed, or `false` if it is unknown, already
+// removed, already run (or running) or if the actions will never run because the Cascade was cancelled.
+//
+// Actions can be removed while the actions are being run, for example by an earlier action.
+func (c *Cascade) RemoveAction(id ActionID) bool {
+	c.muActions.Lock()
+	defer c.muActions.Unlock()
+	l := &c.actions
+	if l.state == actionsRan || l.state == actionsSkipped {
+		return false
+	}
+	for i := l.next; i < len(l.actions); i++ { // Actions before next have been run already
+		if l.actions[i].seq == int64(id) {
+			l.actions = append(l.actions[:i], l.actions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// DoOnCancel adds a function to the list of actions that should be performed when the Cascade is cancelled.
+//
+// Cancel actions follow the same rules as kill actions (see `DoOnKill`): they are added in a FIFO order and
+// will be executed in order, and actions added after all cancel actions have been run are run immediately by
+// the caller.
+//
+// Note: These actions will NOT be run if the Cascade is killed instead of cancelled.
+func (c *Cascade) DoOnCancel(action func()) {
+	c.addAction(&c.cancelActions, action, c.lastSeq.Add(1))
+}
+
+// DoFirstOnCancel adds a function to the list of actions that should be performed when the Cascade is
+// cancelled.
+//
+// Functions are added in a LIFO order and will be executed in order. See `DoFirstOnKill`.
+//
+// Note: These actions will NOT be run if the Cascade is killed instead of cancelled.
+func (c *Cascade) DoFirstOnCancel(action func()) {
+	c.addFirstAction(&c.cancelActions, action, c.firstSeq.Add(-1))
+}
+
+// DoCritical adds a critical action, a function that is run when the Cascade is killed OR cancelled. Critical
+// actions are meant for cleanup that must never be skipped, such as releasing a distributed lock.
+//
+// Critical actions are run in the order they were added, before any `DoOnKill` actions. Each critical action
+// is run even if a previous one panicked: a panic is recovered and, if no error has been set on the Cascade
+// yet, set as its error (see `PanicError`). Critical actions added after the critical actions have been run
+// are run immediately by the caller.
+func (c *Cascade) DoCritical(action func()) {
+	c.muActions.Lock()
+	if c.critState == actionsRan {
+		c.muActions.Unlock()
+		c.runSafely(action)
+		return
+	}
+	c.critical = append(c.critical, action)
+	c.muActions.Unlock()
+}
+
+// DoAlways adds a function to the list of actions that should be performed when the Cascade is killed OR
+// cancelled, such as closing files or flushing buffers.
+//
+// Always actions follow the same rules as kill actions (see `DoOnKill`) and are run after the kill or
+// cancel actions.
+func (c *Cascade) DoAlways(action func()) {
+	c.addAction(&c.alwaysActions, action, c.lastSeq.Add(1))
+}
+
+// DoFirstAlways adds a function to the list of actions that should be performed when the Cascade is killed
+// OR cancelled.
+//
+// Functions are added in a LIFO order and will be executed in order. See `DoFirstOnKill`.
+func (c *Cascade) DoFirstAlways(action func()) {
+	c.addFirstAction(&c.alwaysActions, action, c.firstSeq.Add(-1))
+}
+
+// SetActions replaces all of the actions registered with `DoOnKill` and `DoFirstOnKill` with the
+// provided actions and returns the actions that were replaced. The swap happens atomically, so the
+// Cascade never runs a mix of the old and new actions. The ActionIDs of the replaced actions no longer
+// match any action (see `RemoveAction`).
+//
+// An error will be returned, and the actions will not be replaced, if the actions have already started
+// running or have been skipped because the Cascade was cancelled.
+func (c *Cascade) SetActions(actions []func()) ([]func(), error) {
+	c.muActions.Lock()
+	defer c.muActions.Unlock()
+	if c.actions.state != actionsPending {
+		return nil, errors.New("cascade: actions already run")
+	}
+	previous := make([]func(), 0, len(c.actions.actions))
+	for _, prev := range c.actions.actions {
+		previous = append(previous, prev.run)
+	}
+	c.actions.actions = make([]killAction, 0, len(actions))
+	for _, run := range actions {
+		c.actions.actions = append(c.actions.actions, killAction{run: run, seq: c.lastSeq.Add(1)})
+	}
+	return previous, nil
+}
+
+// SetSequencedActions enables or disables sequenced actions. Every action added with `DoOnKill` or
+// `DoFirstOnKill` gets a sequence number as soon as the call is made, and with sequenced actions enabled the
+// actions are sorted by their sequence numbers when the Cascade is killed. This gives a total order that
+// reflects the order of the calls even when they are made concurrently, rather than the order in which
+// the calls happened to acquire the Cascade's lock.
+//
+// Actions added while the actions are being run are not sorted, they are run as described in `DoOnKill`
+// and `DoFirstOnKill`.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetSequencedActions(enabled bool) {
+	c.muConfig.Lock()
+	c.sequenced = enabled
+	c.muConfig.Unlock()
+}
+
+// SetParallelActions enables or disables parallel actions. With parallel actions enabled, the kill, cancel and
+// always actions (see `DoOnKill`, `DoOnCancel` and `DoAlways`) of the Cascade are each run on their own
+// goroutine instead of one after the other, which speeds up many independent cleanups such as closing
+// connections. The Cascade is still only done (see `Done`) once every action has returned.
+//
+// Parallel actions have no ordering guarantees: `DoFirstOnKill` and sequenced actions (see
+// `SetSequencedActions`) no longer affect the order in which actions run. Actions added by another action
+// while the actions are running are run once the running actions have returned.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetParallelActions(enabled bool) {
+	c.muConfig.Lock()
+	c.parallel = enabled
+	c.muConfig.Unlock()
+}
+
+// DoBeforeKill adds a pre-stop hook that is run as soon as the Cascade is killed, before any of its children
+// are torn down and before the Cascade starts dying. This is meant for announcing that the Cascade is going
+// away (for example deregistering from a load balancer) while everything is still running, whereas
+// `DoOnKill` actions are meant for cleaning up once everything has exited.
+//
+// Hooks are run in the order they were added. Hooks added after the hooks have been run are run
+// immediately by the caller.
+//
+// Note: These hooks will NOT be run if the Cascade is cancelled instead of killed, unless
+// `PreStopOnCancel` is enabled.
+func (c *Cascade) DoBeforeKill(hook func()) {
 	c.muActions.Lock()
-	c.actions = append([]func(){action}, c.actions...)
+	if c.preState == actionsRan {
+		c.muActions.Unlock()
+		hook()
+		return
+	}
+	c.preStop = append(c.preStop, hook)
 	c.muActions.Unlock()
 }
 
+// PreStopOnCancel sets whether the pre-stop hooks (see `DoBeforeKill`) are also run when the Cascade is
+// cancelled. `DoOnKill` actions are never run on cancel regardless of this setting.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) PreStopOnCancel(enabled bool) {
+	c.muConfig.Lock()
+	c.preOnCancel = enabled
+	c.muConfig.Unlock()
+}
+
 // ChildCascade creates a new Cascade which is a child of the current Cascade.
 //
 // The child Cascade being killed or cancelled will not kill or cancel the parent.
-func (c *Cascade) ChildCascade() *Cascade {
+//
+// The child inherits the configuration (such as the `Logger`) that the current Cascade has at the time of creation,
+// options passed to ChildCascade are applied on top of it. Only configuration is inherited: names, errors, values
+// and metadata are not.
+//
+// Note: A child created on a Cascade that has been killed or cancelled is returned already killed or cancelled
+// (the same way as the current Cascade), since the teardown of the current Cascade may already be past its
+// children. Children that its teardown no longer reaches are counted as orphans (see `OrphanCount`).
+// A child created on a draining Cascade (see `Drain`) is returned already cancelled. See `ChildCascadeSafe` for
+// getting an error instead.
+func (c *Cascade) ChildCascade(opts ...Option) *Cascade {
 	child := RootCascade()
-	child.parent = c
+	child.parent.Store(c)
+	c.copyConfig(child)
+	child.apply(opts)
 	c.muChildren.Lock()
-	c.children[child] = nil
+	if c.children == nil {
+		// The current Cascade is already done and will never tear the child down
+		orphans.Add(1)
+	} else {
+		c.children[child] = nil
+		c.notifyChildEvent(ChildAdded, child)
+	}
 	c.muChildren.Unlock()
+	switch {
+	case c.draining.Load():
+		child.rejected = true
+		child.Cancel()
+	case c.IsDead(): // Checked after adding the child, a teardown that starts later is sure to reach it
+		if c.isCancelled() {
+			child.Cancel()
+		} else {
+			child.Kill()
+		}
+	}
 	return child
 }
 
+// ChildCascadeSafe creates a new child Cascade just like `ChildCascade`, except that an error is returned
+// instead of a child that is already dead: `ErrParentDead` if the current Cascade has been killed or cancelled,
+// or `ErrDraining` if it is draining (see `Drain`).
+func (c *Cascade) ChildCascadeSafe(opts ...Option) (*Cascade, error) {
+	if c.draining.Load() {
+		return nil, ErrDraining
+	}
+	if c.IsDead() {
+		return nil, ErrParentDead
+	}
+	child := c.ChildCascade(opts...)
+	if child.IsDead() { // The current Cascade died (or started draining) while the child was being created
+		if child.rejected {
+			return nil, ErrDraining
+		}
+		return nil, ErrParentDead
+	}
+	return child, nil
+}
+
 // Mark marks a goroutine as being tracked by a Cascade. It should be used similar to `Add` in `sync.WaitGroup`
 // and called at the beginning of a goroutine.
 //
@@ -497,28 +1582,44 @@ func (c *Cascade) ChildCascade() *Cascade {
 //
 // A marked goroutine MUST also `UnMark` once it has exited. This is most easily accomplished by using a `defer`.
 //
+// If the Cascade has been quiesced (see `Quiesce`), Mark blocks until `Unquiesce` is called or the Cascade
+// starts dying.
+//
+// If the Cascade is draining (see `Drain`), the goroutine is rejected: Mark returns `false` without marking
+// it. A goroutine that was not marked MUST NOT call `UnMark` and should exit without doing its work.
+//
 // Example of a marked goroutine:
 //  c := RootCascade()
 //  go func() {
-//  	c.Mark()          // Mark the goroutine as tracked
+//  	if !c.Mark() {    // Mark the goroutine as tracked
+//  		return        // The Cascade is draining
+//  	}
 //  	defer c.UnMark()  // UnMark the goroutine once it's done.
 //  	// Do something
 //  	Hold()            // Wait for Cascade kill or cancel.
 //  }()
 //  // Additional Code Not Shown
-func (c *Cascade) Mark() {
-	c.muTracked.Lock()
-	c.tracked++
-	c.muTracked.Unlock()
-	if c.IsDead() {
-		c.muTracked.RLock()
-		if c.tracked == 0 {
-			c.onceDead.Do(func() {
-				close(c.dead)
-			})
+func (c *Cascade) Mark() bool {
+	c.muTracked.RLock()
+	if c.quiesced && c.Alive() {
+		c.muTracked.RUnlock()
+		c.muTracked.Lock()
+		for c.quiesced && c.Alive() {
+			c.condTracked.Wait()
 		}
+		c.muTracked.Unlock()
+		c.muTracked.RLock()
+	}
+	if c.draining.Load() { // Set under the write lock, so Drain either sees this mark or it is rejected
 		c.muTracked.RUnlock()
+		return false
+	}
+	c.track(1)
+	c.muTracked.RUnlock()
+	if c.leakTrack.Load() {
+		c.recordMark()
 	}
+	return true
 }
 
 // UnMark removes the mark from a goroutine being tracked by a Cascade.
@@ -527,23 +1628,220 @@ func (c *Cascade) Mark() {
 //
 // See the docs for `Mark` for a usage example.
 func (c *Cascade) UnMark() {
+	if c.leakTrack.Load() {
+		c.releaseMark()
+	}
+	c.muTracked.RLock()
+	c.track(-1)
+	c.condTracked.Broadcast() // Wake up waitTracked, which checks the count while holding the write lock
+	c.muTracked.RUnlock()
+}
+
+// Adds delta to the tracked count and closes dead if a dead Cascade reached zero tracked goroutines.
+// The caller must hold muTracked.RLock.
+func (c *Cascade) track(delta int64) {
+	tracked := c.tracked.Add(delta)
+	for peak := c.peakTracked.Load(); tracked > peak; peak = c.peakTracked.Load() {
+		if c.peakTracked.CompareAndSwap(peak, tracked) {
+			break
+		}
+	}
+	if tracked == 0 && c.watchers.Load() == 0 && c.IsDead() {
+		c.closeDead()
+	}
+}
+
+// Counts an internal watcher goroutine (such as the one started by KillWhen) that has to exit before the
+// Cascade is dead. Unlike Mark it does not block on a quiesced Cascade and it is not part of TrackedCount,
+// so watchers never show up to users or hold off Quiesce, WaitForMarksBelow or Drain.
+func (c *Cascade) markWatcher() {
+	c.muTracked.RLock()
+	c.watchers.Add(1)
+	c.muTracked.RUnlock()
+}
+
+// Releases a watcher counted by markWatcher, closing dead if it was the last thing the Cascade waited for.
+func (c *Cascade) unmarkWatcher() {
+	c.muTracked.RLock()
+	if c.watchers.Add(-1) == 0 && c.tracked.Load() == 0 && c.IsDead() {
+		c.closeDead()
+	}
+	c.muTracked.RUnlock()
+}
+
+// Closes the dead channel, only the first call has any effect
+func (c *Cascade) closeDead() {
+	c.onceDead.Do(func() {
+		close(c.dead)
+	})
+}
+
+// TrackedCount returns the number of goroutines that are currently being tracked by the Cascade (see `Mark`).
+func (c *Cascade) TrackedCount() int {
+	return int(c.tracked.Load())
+}
+
+// PeakTracked returns the highest number of goroutines that have been tracked by the Cascade at the same time.
+func (c *Cascade) PeakTracked() int {
+	return int(c.peakTracked.Load())
+}
+
+// Quiesce stops the Cascade from accepting new marks and waits for all currently tracked goroutines to
+// `UnMark`. It returns `true` once nothing is tracked, or `false` if the provided duration elapses first.
+//
+// While quiesced, calls to `Mark` on this Cascade (including the ones made by `Wrap` and the `WrapInLoop`
+// variants) block until `Unquiesce` is called, which makes Quiesce suitable for swapping out state that every
+// tracked goroutine reads. Unlike `Kill` or `Cancel`, the Cascade is not signalled as dying and stays alive.
+//
+// Only the Cascade itself is quiesced. `Go` and its variants track their function on a new child, so they are
+// not blocked, and goroutines tracked by children are not waited for.
+//
+// If the duration elapses the Cascade is automatically unquiesced before returning `false`.
+//
+// Warning: Calling Quiesce from a goroutine tracked by the same Cascade will always time out.
+func (c *Cascade) Quiesce(d time.Duration) bool {
 	c.muTracked.Lock()
-	c.tracked--
+	c.quiesced = true
 	c.muTracked.Unlock()
-	if c.IsDead() {
-		c.muTracked.RLock()
-		if c.tracked == 0 {
-			c.onceDead.Do(func() {
-				close(c.dead)
-			})
+	if c.waitTracked(func(tracked int64) bool { return tracked <= 0 }, d) {
+		return true
+	}
+	c.Unquiesce()
+	return false
+}
+
+// Unquiesce allows the Cascade to accept new marks again after a call to `Quiesce`.
+// Any marks that were blocked while the Cascade was quiesced are released.
+func (c *Cascade) Unquiesce() {
+	c.muTracked.Lock()
+	c.quiesced = false
+	c.condTracked.Broadcast()
+	c.muTracked.Unlock()
+}
+
+// WaitForMarksBelow blocks until fewer than `n` goroutines are tracked by the Cascade or until the provided
+// duration elapses. Returns `true` if the tracked count dropped below `n` in time.
+//
+// This can be used for backpressure: hold off on launching more tracked work until the amount of in-flight
+// work drops below a watermark.
+func (c *Cascade) WaitForMarksBelow(n int, d time.Duration) bool {
+	return c.waitTracked(func(tracked int64) bool { return tracked < int64(n) }, d)
+}
+
+// waitTracked blocks until the provided condition holds for the tracked count or until the duration elapses.
+// Returns `true` if the condition was met.
+func (c *Cascade) waitTracked(cond func(tracked int64) bool, d time.Duration) bool {
+	expired := false
+	timer := time.AfterFunc(d, func() {
+		c.muTracked.Lock()
+		expired = true
+		c.condTracked.Broadcast()
+		c.muTracked.Unlock()
+	})
+	defer timer.Stop()
+
+	c.muTracked.Lock()
+	defer c.muTracked.Unlock()
+	for !cond(c.tracked.Load()) {
+		if expired {
+			return false
 		}
-		c.muTracked.RUnlock()
+		c.condTracked.Wait()
 	}
+	return true
 }
 
 // Error returns the error set by one of the `WithError` functions.
+//
+// If no error was set, `ErrKilled` or `ErrCancelled` is returned once the Cascade has been killed or
+// cancelled, and `nil` while it is alive.
 func (c *Cascade) Error() error {
+	if err := c.explicitError(); err != nil {
+		return err
+	}
+	if !c.isDead.Load() {
+		return nil
+	}
+	if c.isCancelled() {
+		return ErrCancelled
+	}
+	return ErrKilled
+}
+
+// Returns the error that was explicitly set on the Cascade, without falling back to ErrKilled or ErrCancelled
+func (c *Cascade) explicitError() error {
 	c.muErr.Lock()
 	defer c.muErr.Unlock()
 	return c.err
 }
+
+// ID returns an identifier that is assigned to the Cascade when it is created and is unique among all Cascades
+// created by the process. Unlike `Name`, the ID is always set and never changes.
+func (c *Cascade) ID() string {
+	return strconv.FormatUint(c.id, 10)
+}
+
+// ChildCascadeNamed creates a new child Cascade (see `ChildCascade`) with the provided name (see `SetName`).
+func (c *Cascade) ChildCascadeNamed(name string) *Cascade {
+	child := c.ChildCascade()
+	child.SetName(name)
+	return child
+}
+
+// SetName sets a descriptive name on the Cascade. Names are only used to identify a Cascade (see `Path`)
+// and have no effect on its behavior.
+func (c *Cascade) SetName(name string) {
+	c.muName.Lock()
+	c.name = name
+	c.muName.Unlock()
+}
+
+// Name returns the name set on the Cascade or an empty string if it has not been named.
+func (c *Cascade) Name() string {
+	c.muName.RLock()
+	defer c.muName.RUnlock()
+	return c.name
+}
+
+// String describes the Cascade and its current state, for example:
+// cascade("worker-pool", children=3, tracked=5, dead=false)
+func (c *Cascade) String() string {
+	return fmt.Sprintf("cascade(%q, children=%d, tracked=%d, dead=%t)", c.Name(), c.ChildCount(), c.TrackedCount(), c.IsDead())
+}
+
+// SetMeta attaches a piece of metadata to the Cascade under the provided key, replacing any previous value.
+//
+// Metadata lives on the Cascade itself for its whole lifetime (it is not inherited by children) and is meant
+// for correlating a Cascade with external systems, such as attaching a trace or tenant ID that actions can
+// later retrieve with `Meta`. It is safe to call SetMeta and Meta concurrently.
+func (c *Cascade) SetMeta(key string, val interface{}) {
+	c.muMeta.Lock()
+	if c.meta == nil {
+		c.meta = make(map[string]interface{})
+	}
+	c.meta[key] = val
+	c.muMeta.Unlock()
+}
+
+// SetValue stores a value on the Cascade under the provided key, replacing any previous value. Like
+// `context.WithValue`, the value is visible through `Value` on the Cascade and all of its descendants unless a
+// descendant stores (or links a Context with) a value under the same key. The key must be comparable and,
+// as with Contexts, should be of an unexported type to avoid collisions.
+//
+// It is safe to call SetValue and Value concurrently.
+func (c *Cascade) SetValue(key, val interface{}) {
+	c.muMeta.Lock()
+	if c.values == nil {
+		c.values = make(map[interface{}]interface{})
+	}
+	c.values[key] = val
+	c.muMeta.Unlock()
+}
+
+// Meta returns the metadata stored under the provided key with `SetMeta` and whether it was found.
+func (c *Cascade) Meta(key string) (interface{}, bool) {
+	c.muMeta.RLock()
+	defer c.muMeta.RUnlock()
+	val, ok := c.meta[key]
+	return val, ok
+}
@@ -1,8 +1,11 @@
 package cascade
 
 import (
+	"context"
 	"errors"
+	"io"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -320,6 +323,143 @@ func TestCascade_Wait(t *testing.T) {
 	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
 }
 
+func TestCascade_WaitDoneDuration(t *testing.T) {
+	cas := RootCascade()
+	if d := cas.TeardownDuration(); d != 0 {
+		t.Errorf("WaitDoneDuration: Expected no duration before teardown, got %v", d)
+	}
+	cas.DoOnKill(func() {
+		time.Sleep(time.Second / 10)
+	})
+
+	go cas.Kill()
+	d := cas.WaitDoneDuration()
+	if d < time.Second/10 || d > time.Second {
+		t.Errorf("WaitDoneDuration: Unexpected teardown duration %v", d)
+	}
+	if cas.TeardownDuration() != d {
+		t.Error("WaitDoneDuration: TeardownDuration does not match!")
+	}
+}
+
+func TestCascade_DyingReason(t *testing.T) {
+	cas := RootCascade()
+	reason := cas.DyingReason()
+	err := errors.New("reason")
+	go cas.KillWithError(err)
+
+	select {
+	case got := <-reason:
+		if got != err {
+			t.Errorf("DyingReason: Expected %v, got %v", err, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("DyingReason: No reason was sent!")
+	}
+	cas.WaitDone()
+	if got := <-cas.DyingReason(); got != err {
+		t.Errorf("DyingReason: Expected %v after dying, got %v", err, got)
+	}
+
+	cas = RootCascade()
+	reason = cas.DyingReason()
+	cas.Kill()
+	if got := <-reason; got != nil {
+		t.Errorf("DyingReason: Expected no reason without an error, got %v", got)
+	}
+	if _, ok := <-reason; ok {
+		t.Error("DyingReason: Channel was not closed!")
+	}
+}
+
+func TestCascade_WaitOrForce(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	stuck := make(chan struct{})
+	defer close(stuck)
+	ranAction := false
+
+	cas.DoOnKill(func() { ranAction = true })
+	child.Mark()
+	go func() {
+		defer child.UnMark()
+		<-stuck // Ignores the exit condition
+	}()
+
+	go cas.Kill()
+	force := make(chan struct{})
+	result := make(chan bool)
+	go func() {
+		result <- cas.WaitOrForce(force)
+	}()
+
+	select {
+	case <-result:
+		t.Fatal("WaitOrForce: Returned before dead or forced!")
+	case <-time.After(time.Second / 10):
+	}
+
+	close(force)
+	if <-result {
+		t.Error("WaitOrForce: Expected the wait to be forced!")
+	}
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Fatal("WaitOrForce: Cascade did not finish after being forced!")
+	}
+	if ranAction {
+		t.Error("WaitOrForce: Pending action was run after being forced!")
+	}
+	if !child.IsDead() {
+		t.Error("WaitOrForce: Child was not forced!")
+	}
+}
+
+func TestCascade_WaitOrForceGraceful(t *testing.T) {
+	cas := RootCascade()
+	go cas.Kill()
+	if !cas.WaitOrForce(make(chan struct{})) {
+		t.Error("WaitOrForceGraceful: Expected the Cascade to die on its own!")
+	}
+	cas.WaitDone()
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_WaitWithTimeout(t *testing.T) {
+	cas := RootCascade()
+	release := make(chan struct{})
+	cas.Mark()
+	go func() {
+		defer cas.UnMark()
+		<-release // Simulate a slow goroutine
+	}()
+
+	if cas.HoldWithTimeout(time.Second / 20) {
+		t.Error("WaitWithTimeout: Hold should have timed out!")
+	}
+	go cas.Kill()
+	if !cas.HoldWithTimeout(time.Second) {
+		t.Error("WaitWithTimeout: Hold timed out!")
+	}
+	if cas.WaitWithTimeout(time.Second / 20) {
+		t.Error("WaitWithTimeout: Wait should have timed out!")
+	}
+	if cas.WaitDoneWithTimeout(time.Second / 20) {
+		t.Error("WaitWithTimeout: WaitDone should have timed out!")
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ { // Repeated calls should keep working
+		if !cas.WaitWithTimeout(time.Second) {
+			t.Error("WaitWithTimeout: Wait timed out!")
+		}
+		if !cas.WaitDoneWithTimeout(time.Second) {
+			t.Error("WaitWithTimeout: WaitDone timed out!")
+		}
+	}
+}
+
 func TestCascade_WaitDone(t *testing.T) {
 	cas := RootCascade()
 	waiter := make(chan struct{}, 0)
@@ -588,6 +728,207 @@ func TestCascade_KillAfterKill(t *testing.T) {
 	cas.Kill() // Should do nothing!
 }
 
+func TestCascade_GoWithError(t *testing.T) {
+	cas := RootCascade()
+	err := errors.New("failed")
+	children := make([]*Cascade, 0)
+	for i := 0; i < 3; i++ {
+		fail := i == 1
+		children = append(children, cas.GoWithError(func(c *Cascade) error {
+			if fail {
+				return err
+			}
+			c.Hold()
+			return nil
+		}))
+	}
+
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Fatal("GoWithError: Cascade was not killed by the error!")
+	}
+	cas.WaitDone()
+	if cas.Error() != err {
+		t.Errorf("GoWithError: Expected %v, got %v", err, cas.Error())
+	}
+	for _, child := range children {
+		if !child.IsDead() {
+			t.Error("GoWithError: Child was not killed!")
+		}
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, true)
+}
+
+func TestCascade_Scope(t *testing.T) {
+	cas := RootCascade()
+	exited := false
+	ranAction := false
+	fErr := errors.New("scope")
+	childErr := errors.New("child")
+
+	err := cas.Scope(func(child *Cascade) error {
+		child.DoOnKill(func() { ranAction = true })
+		child.Go(func(c *Cascade) {
+			c.Hold()
+			exited = true
+		})
+		child.muErr.Lock()
+		child.err = childErr
+		child.muErr.Unlock()
+		return fErr
+	})
+
+	if !exited {
+		t.Error("Scope: Goroutine outlived the scope!")
+	}
+	if !ranAction {
+		t.Error("Scope: Actions were not run!")
+	}
+	if !errors.Is(err, fErr) || !errors.Is(err, childErr) {
+		t.Errorf("Scope: Expected both errors, got %v", err)
+	}
+	verifyCascadeEndState(t, cas, false, 0, false, 0, false, 0, false)
+
+	if err := cas.Scope(func(*Cascade) error { return nil }); err != nil {
+		t.Errorf("Scope: Expected no error, got %v", err)
+	}
+	cas.Kill()
+}
+
+func TestCascade_Close(t *testing.T) {
+	var closer io.Closer = RootCascade()
+	if err := closer.Close(); err != nil {
+		t.Errorf("Close: Expected no error, got %v", err)
+	}
+	verifyCascadeEndState(t, closer.(*Cascade), false, 0, true, 0, false, 0, false)
+
+	cas := RootCascade()
+	err := errors.New("close")
+	cas.muErr.Lock()
+	cas.err = err
+	cas.muErr.Unlock()
+	if casErr := cas.Close(); casErr != err {
+		t.Errorf("Close: Expected %v, got %v", err, casErr)
+	}
+}
+
+func TestCascade_KillWhen(t *testing.T) {
+	cas := RootCascade()
+	other := RootCascade()
+	cas.KillWhen(other)
+	if cas.IsDead() {
+		t.Error("KillWhen: Cascade died before the other Cascade!")
+	}
+
+	other.Kill()
+	ok := didExitBeforeTime(cas, time.Second/2)
+	if !ok {
+		t.Error("KillWhen: Cascade was not killed when the other Cascade died!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_KillWhenDiesFirst(t *testing.T) {
+	cas := RootCascade()
+	other := RootCascade()
+	cas.KillWhen(other)
+
+	cas.Kill()
+	ok := didExitBeforeTime(cas, time.Second/2)
+	if !ok {
+		t.Error("KillWhenDiesFirst: Watcher did not exit!")
+	}
+	if other.IsDead() {
+		t.Error("KillWhenDiesFirst: The other Cascade was killed!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+	other.Kill()
+}
+
+func TestCascade_KillAfter(t *testing.T) {
+	cas := RootCascade()
+	ran := false
+	cas.DoOnKill(func() { ran = true })
+	cas.KillAfter(time.Second / 10)
+	if cas.IsDead() {
+		t.Error("KillAfter: Cascade was killed right away!")
+	}
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Fatal("KillAfter: Cascade was not killed!")
+	}
+	if !ran {
+		t.Error("KillAfter: Actions were not run!")
+	}
+
+	cas = RootCascade()
+	timer := cas.KillAfter(time.Second / 10)
+	if !timer.Stop() {
+		t.Error("KillAfter: Timer could not be stopped!")
+	}
+	time.Sleep(time.Second / 5)
+	if cas.IsDead() {
+		t.Error("KillAfter: Stopped timer killed the Cascade!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_CancelAfter(t *testing.T) {
+	cas := RootCascade()
+	ran := false
+	cas.DoOnKill(func() { ran = true })
+	cas.CancelAfter(time.Second / 10)
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Fatal("CancelAfter: Cascade was not cancelled!")
+	}
+	if ran {
+		t.Error("CancelAfter: Actions were run!")
+	}
+}
+
+func TestCascade_KillContext(t *testing.T) {
+	cas := RootCascade()
+	if err := cas.KillContext(context.TODO()); err != nil {
+		t.Errorf("KillContext: Expected no error, got %v", err)
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_KillContextCancelled(t *testing.T) {
+	cas := RootCascade()
+	release := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	wg.Add(1)
+	go func() {
+		cas.Mark()
+		defer cas.UnMark()
+		wg.Done()
+		cas.Hold()
+		<-release // Simulate a slow teardown
+	}()
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second/4)
+	defer cancel()
+	if err := cas.KillContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("KillContextCancelled: Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if !cas.IsDead() {
+		t.Error("KillContextCancelled: Cascade was not killed!")
+	}
+
+	close(release)
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Error("KillContextCancelled: Kill did not continue in the background!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
 func TestCascade_KillWithError(t *testing.T) {
 	cas := RootCascade()
 	err := errors.New("kill")
@@ -615,14 +956,125 @@ func TestCascade_KillWithErrorWithError(t *testing.T) {
 	if casErr == nil {
 		t.Error("KillWithErrorWithError: Didn't get error for incorrect Kill!")
 	}
-	go cas.Kill()
-	ok := didExitBeforeTime(cas, time.Second/2)
-	if !ok {
-		t.Error("KillWithErrorWithError: Got stuck!")
+	var setErr *AlreadySetError
+	if !errors.As(casErr, &setErr) || setErr.Existing != cas.Error() || setErr.Dropped != err {
+		t.Errorf("KillWithErrorWithError: Expected the existing and dropped errors, got %v", casErr)
+	}
+	// The Cascade is killed regardless, keeping the existing error
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, true)
+	if cas.Error().Error() != "another error" {
+		t.Errorf("KillWithErrorWithError: Expected the existing error to be kept, got %v", cas.Error())
+	}
+}
+
+func TestCascade_KillWithErrorConcurrent(t *testing.T) {
+	cas := RootCascade()
+	errs := []error{errors.New("first"), errors.New("second")}
+	results := make(chan error, len(errs))
+	for _, err := range errs {
+		go func(err error) {
+			results <- cas.KillWithError(err)
+		}(err)
+	}
+
+	lost := 0
+	for range errs {
+		var setErr *AlreadySetError
+		if casErr := <-results; errors.As(casErr, &setErr) {
+			lost++
+			if setErr.Existing != cas.Error() {
+				t.Errorf("KillWithErrorConcurrent: Expected the winning error %v, got %v", cas.Error(), setErr.Existing)
+			}
+		} else if casErr != nil {
+			t.Errorf("KillWithErrorConcurrent: Unexpected error %v", casErr)
+		}
+	}
+	if lost != 1 {
+		t.Errorf("KillWithErrorConcurrent: Expected exactly 1 call to lose, %v did", lost)
 	}
 	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, true)
 }
 
+func TestCascade_KillWithCause(t *testing.T) {
+	cas := RootCascade()
+	ctx := cas.Context(nil)
+	err := errors.New("cause")
+
+	if casErr := cas.KillWithCause(err); casErr != nil {
+		t.Errorf("KillWithCause: Unable to kill with cause: %v", casErr)
+	}
+	if !didExitBeforeTime(cas, time.Second/2) {
+		t.Error("KillWithCause: Got stuck!")
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second / 2):
+		t.Fatal("KillWithCause: Context was not cancelled!")
+	}
+	if cas.Error() != err {
+		t.Errorf("KillWithCause: Expected error %v, got %v", err, cas.Error())
+	}
+	if cause := context.Cause(ctx); cause != cas.Error() {
+		t.Errorf("KillWithCause: Context cause %v does not match error %v", cause, cas.Error())
+	}
+	if ctx.Err() != context.Canceled {
+		t.Errorf("KillWithCause: Expected Context error %v, got %v", context.Canceled, ctx.Err())
+	}
+
+	// Contexts created after the fact should report the same cause.
+	if cause := context.Cause(cas.Context(context.TODO())); cause != err {
+		t.Errorf("KillWithCause: Late Context cause %v does not match error %v", cause, err)
+	}
+
+	if casErr := cas.KillWithCause(errors.New("late")); casErr != ErrAlreadyDead {
+		t.Errorf("KillWithCause: Expected %v for killing a dead Cascade, got %v", ErrAlreadyDead, casErr)
+	}
+	if cas.Error() != err {
+		t.Error("KillWithCause: Error was replaced on a dead Cascade!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, true, 0, true)
+}
+
+func TestCascade_KillWithCauseWithError(t *testing.T) {
+	cas := RootCascade()
+	ctx := cas.Context(nil)
+	existing := errors.New("another error")
+	cas.muErr.Lock()
+	cas.err = existing
+	cas.muErr.Unlock()
+	var setErr *AlreadySetError
+	if casErr := cas.KillWithCause(errors.New("cause")); !errors.As(casErr, &setErr) {
+		t.Errorf("KillWithCauseWithError: Expected an *AlreadySetError, got %v", casErr)
+	} else if setErr.Existing != existing {
+		t.Errorf("KillWithCauseWithError: Expected the existing error, got %v", setErr.Existing)
+	}
+	if !cas.IsDead() {
+		t.Error("KillWithCauseWithError: Cascade was not killed!")
+	}
+	cas.WaitDone()
+	if cause := context.Cause(ctx); cause != existing || cas.Error() != existing {
+		t.Errorf("KillWithCauseWithError: Context cause %v does not match error %v", cause, cas.Error())
+	}
+}
+
+func TestCascade_KillWithCauseJoining(t *testing.T) {
+	cas := RootCascade(WithErrorJoining())
+	ctx := cas.Context(nil)
+	first, cause := errors.New("first"), errors.New("cause")
+	cas.AddError(first)
+	if casErr := cas.KillWithCause(cause); casErr != nil {
+		t.Errorf("KillWithCauseJoining: Expected the error to be joined, got %v", casErr)
+	}
+	cas.WaitDone()
+	if !errors.Is(cas.Error(), first) || !errors.Is(cas.Error(), cause) {
+		t.Errorf("KillWithCauseJoining: Expected both errors, got %v", cas.Error())
+	}
+	if context.Cause(ctx) != cas.Error() {
+		t.Errorf("KillWithCauseJoining: Context cause %v does not match error %v", context.Cause(ctx), cas.Error())
+	}
+}
+
 func TestCascade_Cancel(t *testing.T) {
 	cas := RootCascade()
 	child := cas.ChildCascade()
@@ -1205,36 +1657,404 @@ func TestCascade_DoFirstOnKill(t *testing.T) {
 	verifyCascadeEndState(t, cas, false, 0, true, 3, false, 0, false)
 }
 
-func TestCascade_ChildCascade(t *testing.T) {
+func TestCascade_DoOnKillReentrant(t *testing.T) {
 	cas := RootCascade()
-	child1 := cas.ChildCascade()
-	child2 := child1.ChildCascade()
-	child3 := child1.ChildCascade()
-	child4 := child3.ChildCascade()
-	child5 := child3.ChildCascade()
-	child6 := child3.ChildCascade()
+	order := make([]int, 0)
+	mu := sync.Mutex{}
+	record := func(i int) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+		}
+	}
 
-	if child1.parent != cas {
-		t.Error("ChildCascade: Parent Did Not Match!")
+	cas.DoOnKill(func() {
+		record(1)()
+		cas.DoOnKill(record(4))      // Runs after the already queued actions
+		cas.DoFirstOnKill(record(2)) // Runs next
+	})
+	cas.DoOnKill(record(3))
+
+	// A tracked goroutine that adds an action once the Cascade is dying.
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		cas.Mark()
+		defer cas.UnMark()
+		wg.Done()
+		cas.Hold()
+		cas.DoOnKill(record(5))
+	}()
+	wg.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		cas.Kill()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("DoOnKillReentrant: Got stuck in Kill!")
 	}
-	if child2.parent != child1 {
-		t.Error("ChildCascade: Parent Did Not Match!")
+
+	mu.Lock()
+	got := append([]int(nil), order...)
+	mu.Unlock()
+	want := []int{1, 2, 3, 5, 4}
+	if len(got) != len(want) {
+		t.Fatalf("DoOnKillReentrant: Expected actions %v, got %v", want, got)
 	}
-	if child3.parent != child1 {
-		t.Error("ChildCascade: Parent Did Not Match!")
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("DoOnKillReentrant: Expected actions %v, got %v", want, got)
+		}
 	}
-	if child4.parent != child3 {
-		t.Error("ChildCascade: Parent Did Not Match!")
+
+	ran := false
+	cas.DoOnKill(func() { ran = true })
+	if !ran {
+		t.Error("DoOnKillReentrant: Action added after actions ran was not run immediately!")
 	}
-	if child5.parent != child3 {
-		t.Error("ChildCascade: Parent Did Not Match!")
+	ran = false
+	cas.DoFirstOnKill(func() { ran = true })
+	if !ran {
+		t.Error("DoOnKillReentrant: First action added after actions ran was not run immediately!")
 	}
-	if child6.parent != child3 {
-		t.Error("ChildCascade: Parent Did Not Match!")
+}
+
+func TestCascade_StepActions(t *testing.T) {
+	cas := RootCascade()
+	step := make(chan struct{})
+	ran := make(chan string, 4)
+	action := func(name string) func() {
+		return func() {
+			ran <- name
+		}
 	}
 
-	cas.muChildren.Lock()
-	if _, ok := cas.children[child1]; !ok {
+	cas.DoOnKill(action("kill1"))
+	cas.DoOnKill(action("kill2"))
+	cas.DoFirstOnKill(action("first1"))
+	cas.DoFirstOnKill(action("first2"))
+	cas.stepActions = step
+
+	go cas.Kill()
+
+	for _, want := range []string{"first2", "first1", "kill1", "kill2"} {
+		select {
+		case name := <-ran:
+			t.Fatalf("StepActions: Action %q ran without being stepped!", name)
+		case <-time.After(time.Second / 20):
+		}
+		select {
+		case step <- struct{}{}:
+		case <-time.After(time.Second):
+			t.Fatal("StepActions: Actions did not wait for a step!")
+		}
+		select {
+		case name := <-ran:
+			if name != want {
+				t.Errorf("StepActions: Expected action %q, got %q", want, name)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("StepActions: Action %q did not run after being stepped!", want)
+		}
+	}
+
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Error("StepActions: Got stuck after the last action!")
+	}
+}
+
+func TestCascade_DoOnKillAfterCancel(t *testing.T) {
+	cas := RootCascade()
+	cas.Cancel()
+
+	ran := false
+	cas.DoOnKill(func() { ran = true })
+	cas.Kill()
+	if ran {
+		t.Error("DoOnKillAfterCancel: Action ran on a cancelled Cascade!")
+	}
+}
+
+func TestCascade_SetSequencedActions(t *testing.T) {
+	cas := RootCascade()
+	cas.SetSequencedActions(true)
+	child := cas.ChildCascade()
+	mu := sync.Mutex{}
+	ran := make([]bool, 0)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(first bool) {
+			defer wg.Done()
+			action := func() {
+				mu.Lock()
+				ran = append(ran, first)
+				mu.Unlock()
+			}
+			if first {
+				child.DoFirstOnKill(action)
+			} else {
+				child.DoOnKill(action)
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+
+	cas.Kill()
+	if len(ran) != 50 {
+		t.Fatalf("SetSequencedActions: Expected 50 actions to run, %v did", len(ran))
+	}
+	for i := range ran {
+		if ran[i] != (i < 25) {
+			t.Fatalf("SetSequencedActions: DoFirstOnKill actions should all run first: %v", ran)
+		}
+	}
+	for i := 1; i < len(child.actions.actions); i++ {
+		if child.actions.actions[i-1].seq >= child.actions.actions[i].seq {
+			t.Fatal("SetSequencedActions: Actions did not run in sequence!")
+		}
+	}
+}
+
+func TestCascade_DoCritical(t *testing.T) {
+	cas := RootCascade()
+	order := make([]string, 0)
+	cas.DoOnKill(func() { order = append(order, "action") })
+	cas.DoCritical(func() {
+		order = append(order, "critical1")
+		panic("boom")
+	})
+	cas.DoCritical(func() { order = append(order, "critical2") })
+	cas.Kill()
+	cas.DoCritical(func() { order = append(order, "after") })
+
+	want := []string{"critical1", "critical2", "action", "after"}
+	if len(order) != len(want) {
+		t.Fatalf("DoCritical: Expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("DoCritical: Expected %v, got %v", want, order)
+		}
+	}
+	var panicErr *PanicError
+	if !errors.As(cas.Error(), &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("DoCritical: Expected the panic as error, got %v", cas.Error())
+	}
+}
+
+func TestCascade_DoCriticalOnCancel(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	ranCritical := false
+	ranAction := false
+	child.DoCritical(func() { ranCritical = true })
+	child.DoOnKill(func() { ranAction = true })
+	cas.Cancel()
+	if !ranCritical {
+		t.Error("DoCriticalOnCancel: Critical action did not run on cancel!")
+	}
+	if ranAction {
+		t.Error("DoCriticalOnCancel: Action ran on a cancelled Cascade!")
+	}
+}
+
+func TestCascade_DoOnCancel(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	order := make([]string, 0)
+	mu := sync.Mutex{}
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+	child.DoOnKill(record("kill"))
+	child.DoOnCancel(record("cancel2"))
+	child.DoFirstOnCancel(record("cancel1"))
+
+	cas.Cancel()
+	child.DoOnCancel(record("after"))
+	want := []string{"cancel1", "cancel2", "after"}
+	if len(order) != len(want) {
+		t.Fatalf("DoOnCancel: Expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("DoOnCancel: Expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestCascade_DoOnCancelOnKill(t *testing.T) {
+	cas := RootCascade()
+	ranKill := false
+	ranCancel := false
+	cas.DoOnKill(func() { ranKill = true })
+	cas.DoOnCancel(func() { ranCancel = true })
+	cas.Kill()
+	cas.DoOnCancel(func() { ranCancel = true })
+	if !ranKill {
+		t.Error("DoOnCancelOnKill: Kill action did not run!")
+	}
+	if ranCancel {
+		t.Error("DoOnCancelOnKill: Cancel action ran on a killed Cascade!")
+	}
+}
+
+func TestCascade_DoAlways(t *testing.T) {
+	for _, kill := range []bool{true, false} {
+		cas := RootCascade()
+		order := make([]string, 0)
+		cas.DoAlways(func() { order = append(order, "always2") })
+		cas.DoFirstAlways(func() { order = append(order, "always1") })
+		cas.DoOnKill(func() { order = append(order, "kill") })
+		cas.DoOnCancel(func() { order = append(order, "cancel") })
+
+		want := []string{"kill", "always1", "always2"}
+		if kill {
+			cas.Kill()
+			cas.Kill()
+		} else {
+			want[0] = "cancel"
+			cas.Cancel()
+			cas.Cancel()
+		}
+		if len(order) != len(want) {
+			t.Fatalf("DoAlways: Expected %v, got %v", want, order)
+		}
+		for i := range want {
+			if order[i] != want[i] {
+				t.Fatalf("DoAlways: Expected %v, got %v", want, order)
+			}
+		}
+	}
+}
+
+func TestCascade_SetActions(t *testing.T) {
+	cas := RootCascade()
+	ran := make([]string, 0)
+	cas.DoOnKill(func() { ran = append(ran, "old") })
+
+	previous, err := cas.SetActions([]func(){
+		func() { ran = append(ran, "new1") },
+		func() { ran = append(ran, "new2") },
+	})
+	if err != nil {
+		t.Fatalf("SetActions: Unable to set actions: %v", err)
+	}
+	if len(previous) != 1 {
+		t.Errorf("SetActions: Expected 1 previous action, got %v", len(previous))
+	}
+
+	cas.Kill()
+	if len(ran) != 2 || ran[0] != "new1" || ran[1] != "new2" {
+		t.Errorf("SetActions: Expected only the new actions to run, got %v", ran)
+	}
+	if _, err := cas.SetActions(nil); err == nil {
+		t.Error("SetActions: Didn't get error after actions ran!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 2, false, 0, false)
+}
+
+func TestCascade_DoBeforeKill(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	var order []string
+
+	cas.DoBeforeKill(func() {
+		select {
+		case <-cas.Dying():
+			order = append(order, "late")
+			return
+		default:
+		}
+		if child.IsDead() {
+			order = append(order, "late")
+			return
+		}
+		order = append(order, "pre-stop")
+	})
+	cas.DoOnKill(func() { order = append(order, "action") })
+	cas.Kill()
+	cas.DoBeforeKill(func() { order = append(order, "after") })
+
+	want := []string{"pre-stop", "action", "after"}
+	if len(order) != len(want) {
+		t.Fatalf("DoBeforeKill: Expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("DoBeforeKill: Expected %v, got %v", want, order)
+			break
+		}
+	}
+}
+
+func TestCascade_DoBeforeKillOnCancel(t *testing.T) {
+	cas := RootCascade()
+	ran := false
+	cas.DoBeforeKill(func() { ran = true })
+	cas.Cancel()
+	if ran {
+		t.Error("DoBeforeKillOnCancel: Pre-stop hook ran on a cancelled Cascade!")
+	}
+
+	cas = RootCascade()
+	cas.PreStopOnCancel(true)
+	child := cas.ChildCascade()
+	ranAction := false
+	child.DoBeforeKill(func() { ran = true })
+	child.DoOnKill(func() { ranAction = true })
+	cas.Cancel()
+	if !ran {
+		t.Error("DoBeforeKillOnCancel: Pre-stop hook did not run with PreStopOnCancel!")
+	}
+	if ranAction {
+		t.Error("DoBeforeKillOnCancel: Action ran on a cancelled Cascade!")
+	}
+}
+
+func TestCascade_ChildCascade(t *testing.T) {
+	cas := RootCascade()
+	child1 := cas.ChildCascade()
+	child2 := child1.ChildCascade()
+	child3 := child1.ChildCascade()
+	child4 := child3.ChildCascade()
+	child5 := child3.ChildCascade()
+	child6 := child3.ChildCascade()
+
+	if child1.Parent() != cas {
+		t.Error("ChildCascade: Parent Did Not Match!")
+	}
+	if child2.Parent() != child1 {
+		t.Error("ChildCascade: Parent Did Not Match!")
+	}
+	if child3.Parent() != child1 {
+		t.Error("ChildCascade: Parent Did Not Match!")
+	}
+	if child4.Parent() != child3 {
+		t.Error("ChildCascade: Parent Did Not Match!")
+	}
+	if child5.Parent() != child3 {
+		t.Error("ChildCascade: Parent Did Not Match!")
+	}
+	if child6.Parent() != child3 {
+		t.Error("ChildCascade: Parent Did Not Match!")
+	}
+
+	cas.muChildren.Lock()
+	if _, ok := cas.children[child1]; !ok {
 		t.Error("ChildCascade: Child Did Not Match!")
 	}
 	cas.muChildren.Unlock()
@@ -1281,7 +2101,7 @@ func TestCascade_Mark(t *testing.T) {
 
 	wg.Wait()
 	cas.muTracked.Lock()
-	if cas.tracked != 1 {
+	if cas.tracked.Load() != 1 {
 		t.Error("Mark: Mark did not increment Tracking!")
 	}
 	cas.muTracked.Unlock()
@@ -1329,6 +2149,46 @@ func TestCascade_MarkEmptyDead(t *testing.T) {
 	}()
 }
 
+func TestCascade_MarkStress(t *testing.T) {
+	cas := RootCascade()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				cas.Mark()
+				cas.UnMark()
+			}
+		}()
+	}
+	wg.Wait()
+	if cas.TrackedCount() != 0 {
+		t.Errorf("MarkStress: Expected 0 tracked, got %d", cas.TrackedCount())
+	}
+	if cas.PeakTracked() < 1 || cas.PeakTracked() > 32 {
+		t.Errorf("MarkStress: Unexpected peak of %d", cas.PeakTracked())
+	}
+
+	// Marks racing with the kill must still let the Cascade become dead
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				cas.Mark()
+				cas.UnMark()
+			}
+		}()
+	}
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 5*time.Second) {
+		t.Error("MarkStress: Got stuck in Kill!")
+	}
+	wg.Wait()
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
 func TestCascade_UnMark(t *testing.T) {
 	cas := RootCascade()
 	wg := sync.WaitGroup{}
@@ -1350,7 +2210,7 @@ func TestCascade_UnMark(t *testing.T) {
 	}
 
 	cas.muTracked.Lock()
-	if cas.tracked != 0 {
+	if cas.tracked.Load() != 0 {
 		t.Error("UnMark: UnMark did not decrement Tracking!")
 	}
 	cas.muTracked.Unlock()
@@ -1358,6 +2218,240 @@ func TestCascade_UnMark(t *testing.T) {
 	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
 }
 
+func TestCascade_TrackedCount(t *testing.T) {
+	cas := RootCascade()
+	for i := 0; i < 3; i++ {
+		cas.Mark()
+	}
+	if cas.TrackedCount() != 3 {
+		t.Errorf("TrackedCount: Expected 3, got %v", cas.TrackedCount())
+	}
+	for i := 0; i < 3; i++ {
+		cas.UnMark()
+	}
+	if cas.TrackedCount() != 0 {
+		t.Errorf("TrackedCount: Expected 0, got %v", cas.TrackedCount())
+	}
+
+	cas.ChildCascade()
+	cas.ChildCascade()
+	if cas.ChildCount() != 2 {
+		t.Errorf("TrackedCount: Expected 2 children, got %v", cas.ChildCount())
+	}
+	cas.Kill()
+	if cas.ChildCount() != 0 {
+		t.Errorf("TrackedCount: Expected no children after kill, got %v", cas.ChildCount())
+	}
+}
+
+func TestCascade_PeakTracked(t *testing.T) {
+	cas := RootCascade()
+	if peak := cas.PeakTracked(); peak != 0 {
+		t.Errorf("PeakTracked: Expected 0, got %v", peak)
+	}
+
+	cas.Mark()
+	cas.Mark()
+	cas.Mark()
+	cas.UnMark()
+	cas.UnMark()
+	cas.Mark()
+	if peak := cas.PeakTracked(); peak != 3 {
+		t.Errorf("PeakTracked: Expected 3, got %v", peak)
+	}
+
+	cas.UnMark()
+	cas.UnMark()
+	if peak := cas.PeakTracked(); peak != 3 {
+		t.Errorf("PeakTracked: Expected 3 after unmarking, got %v", peak)
+	}
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("PeakTracked: Got stuck in Kill!")
+	}
+}
+
+func TestCascade_WaitForMarksBelow(t *testing.T) {
+	cas := RootCascade()
+	release := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			cas.Mark()
+			defer cas.UnMark()
+			wg.Done()
+			<-release
+		}()
+	}
+	wg.Wait()
+
+	if cas.WaitForMarksBelow(3, time.Second/10) {
+		t.Error("WaitForMarksBelow: Returned true with 3 tracked goroutines!")
+	}
+	if !cas.WaitForMarksBelow(4, time.Second/10) {
+		t.Error("WaitForMarksBelow: Returned false with 3 tracked goroutines!")
+	}
+
+	below := make(chan bool)
+	go func() {
+		below <- cas.WaitForMarksBelow(1, 2*time.Second)
+	}()
+	close(release)
+	select {
+	case ok := <-below:
+		if !ok {
+			t.Error("WaitForMarksBelow: Timed out after every goroutine exited!")
+		}
+	case <-time.After(time.Second):
+		t.Error("WaitForMarksBelow: Got stuck!")
+	}
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("WaitForMarksBelow: Got stuck in Kill!")
+	}
+}
+
+func TestCascade_Quiesce(t *testing.T) {
+	cas := RootCascade()
+	release := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	wg.Add(1)
+	go func() {
+		cas.Mark()
+		defer cas.UnMark()
+		wg.Done()
+		<-release
+	}()
+	wg.Wait()
+
+	quiesced := make(chan bool)
+	go func() {
+		quiesced <- cas.Quiesce(2 * time.Second)
+	}()
+
+	// Wait for the gate to be in place before trying to mark.
+	for {
+		cas.muTracked.RLock()
+		q := cas.quiesced
+		cas.muTracked.RUnlock()
+		if q {
+			break
+		}
+		<-time.After(time.Millisecond)
+	}
+
+	marked := make(chan struct{})
+	go func() {
+		cas.Mark()
+		close(marked)
+	}()
+
+	select {
+	case <-marked:
+		t.Error("Quiesce: Mark was not blocked!")
+	case <-time.After(time.Second / 4):
+	}
+
+	close(release)
+	select {
+	case ok := <-quiesced:
+		if !ok {
+			t.Error("Quiesce: Did not report success!")
+		}
+	case <-time.After(time.Second):
+		t.Error("Quiesce: Got stuck!")
+	}
+
+	select {
+	case <-marked:
+		t.Error("Quiesce: Mark was released before Unquiesce!")
+	case <-time.After(time.Second / 4):
+	}
+
+	cas.Unquiesce()
+	select {
+	case <-marked:
+	case <-time.After(time.Second):
+		t.Error("Quiesce: Mark was not released by Unquiesce!")
+	}
+	cas.UnMark()
+
+	if cas.IsDead() {
+		t.Error("Quiesce: Cascade died!")
+	}
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("Quiesce: Got stuck in Kill!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_QuiesceTimeout(t *testing.T) {
+	cas := RootCascade()
+	wg := sync.WaitGroup{}
+
+	wg.Add(1)
+	go func() {
+		cas.Mark()
+		defer cas.UnMark()
+		wg.Done()
+		cas.Hold()
+	}()
+	wg.Wait()
+
+	if cas.Quiesce(time.Second / 4) {
+		t.Error("QuiesceTimeout: Reported success with a tracked goroutine!")
+	}
+
+	marked := make(chan struct{})
+	go func() {
+		cas.Mark()
+		cas.UnMark()
+		close(marked)
+	}()
+	select {
+	case <-marked:
+	case <-time.After(time.Second):
+		t.Error("QuiesceTimeout: Cascade was left quiesced!")
+	}
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("QuiesceTimeout: Got stuck in Kill!")
+	}
+}
+
+func TestCascade_QuiesceKill(t *testing.T) {
+	cas := RootCascade()
+	if !cas.Quiesce(time.Second) {
+		t.Error("QuiesceKill: Quiesce failed with nothing tracked!")
+	}
+
+	marked := make(chan struct{})
+	go func() {
+		cas.Mark()
+		defer cas.UnMark()
+		close(marked)
+		cas.Hold()
+	}()
+
+	go cas.Kill()
+	select {
+	case <-marked:
+	case <-time.After(time.Second):
+		t.Error("QuiesceKill: Mark was not released by Kill!")
+	}
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("QuiesceKill: Got stuck in Kill!")
+	}
+}
+
 func TestCascade_Error(t *testing.T) {
 	cas := RootCascade()
 	err := errors.New("error")
@@ -1393,6 +2487,220 @@ func TestCascade_Error(t *testing.T) {
 	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, true)
 }
 
+func TestCascade_IsDeadConcurrentKill(t *testing.T) {
+	cas := RootCascade()
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_ = cas.IsDead()
+					_ = cas.Alive()
+				}
+			}
+		}()
+	}
+	kills := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		kills.Add(1)
+		go func() {
+			defer kills.Done()
+			cas.Kill()
+		}()
+	}
+	kills.Wait()
+	close(stop)
+	wg.Wait()
+	if !cas.IsDead() {
+		t.Error("IsDeadConcurrentKill: Cascade should be dead!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_Named(t *testing.T) {
+	cas := RootCascadeNamed("root")
+	pool := cas.ChildCascadeNamed("worker-pool")
+	worker := pool.ChildCascadeNamed("worker")
+	if cas.Name() != "root" || pool.Name() != "worker-pool" {
+		t.Error("Named: Names were not set!")
+	}
+	if worker.Path() != "root/worker-pool/worker" {
+		t.Errorf("Named: Unexpected path %s", worker.Path())
+	}
+
+	pool.ChildCascade()
+	pool.Mark()
+	if s := pool.String(); s != `cascade("worker-pool", children=2, tracked=1, dead=false)` {
+		t.Errorf("String: Unexpected description %s", s)
+	}
+	pool.UnMark()
+	pool.Kill()
+	if s := pool.String(); s != `cascade("worker-pool", children=0, tracked=0, dead=true)` {
+		t.Errorf("String: Unexpected description %s", s)
+	}
+	cas.Kill()
+}
+
+func TestCascade_KillWithTimeout(t *testing.T) {
+	cas := RootCascade()
+	if err := cas.KillWithTimeout(time.Second); err != nil {
+		t.Errorf("KillWithTimeout: Unexpected error %v", err)
+	}
+
+	cas = RootCascadeNamed("stuck")
+	release := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		cas.Mark()
+		go func() {
+			defer cas.UnMark()
+			<-release // Ignores Dying
+		}()
+	}
+	err := cas.KillWithTimeout(50 * time.Millisecond)
+	var timeout *KillTimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("KillWithTimeout: Expected a KillTimeoutError, got %v", err)
+	}
+	if timeout.Tracked != 2 || timeout.Path != "stuck" {
+		t.Errorf("KillWithTimeout: Unexpected error %v", err)
+	}
+	select {
+	case <-cas.Dying():
+	default:
+		t.Error("KillWithTimeout: Cascade should be left dying!")
+	}
+
+	close(release)
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Error("KillWithTimeout: Late goroutines should still let the Cascade die!")
+	}
+}
+
+func TestCascade_ShouldExit(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	release := make(chan struct{})
+	child.Mark()
+	go func() {
+		defer child.UnMark()
+		<-release // Holds up the teardown of the parent
+	}()
+	if cas.ShouldExit() {
+		t.Error("ShouldExit: Alive Cascade should not exit!")
+	}
+
+	go cas.Kill()
+	for cas.Alive() {
+		time.Sleep(time.Millisecond)
+	}
+	if cas.ShouldExit() {
+		t.Error("ShouldExit: Should not exit while the children are tearing down!")
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		cas.WrapInLoop(func() {})
+		close(exited)
+	}()
+	close(release)
+	<-exited
+	if !cas.ShouldExit() {
+		t.Error("ShouldExit: Should exit once the loop would!")
+	}
+	cas.WaitDone()
+}
+
+func TestCascade_ErrorSentinels(t *testing.T) {
+	cas := RootCascade()
+	if cas.Error() != nil {
+		t.Error("ErrorSentinels: Alive Cascade should have no error!")
+	}
+	cas.Kill()
+	if !errors.Is(cas.Error(), ErrKilled) {
+		t.Errorf("ErrorSentinels: Expected %v, got %v", ErrKilled, cas.Error())
+	}
+
+	cas = RootCascade()
+	cas.Cancel()
+	if !errors.Is(cas.Error(), ErrCancelled) {
+		t.Errorf("ErrorSentinels: Expected %v, got %v", ErrCancelled, cas.Error())
+	}
+	cas.Kill()
+	if !errors.Is(cas.Error(), ErrCancelled) {
+		t.Error("ErrorSentinels: Killing a cancelled Cascade should not change its error!")
+	}
+
+	cas = RootCascade()
+	err := errors.New("explicit")
+	cas.KillWithError(err)
+	if cas.Error() != err {
+		t.Errorf("ErrorSentinels: Expected %v, got %v", err, cas.Error())
+	}
+	if cas.CancelWithError(errors.New("other")) == nil {
+		t.Error("ErrorSentinels: Explicit error was replaced!")
+	}
+}
+
+func TestCascade_ID(t *testing.T) {
+	cas := RootCascade()
+	seen := map[string]bool{cas.ID(): true}
+	for i := 0; i < 100; i++ {
+		id := cas.ChildCascade().ID()
+		if id == "" || seen[id] {
+			t.Fatalf("ID: Duplicate or empty ID %q!", id)
+		}
+		seen[id] = true
+	}
+	cas.SetName("named")
+	if id := cas.ID(); !seen[id] {
+		t.Error("ID: ID changed!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_Meta(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+
+	if _, ok := cas.Meta("trace"); ok {
+		t.Error("Meta: Found metadata that was never set!")
+	}
+
+	cas.SetMeta("trace", "abc")
+	cas.SetMeta("tenant", 42)
+	if val, ok := cas.Meta("trace"); !ok || val != "abc" {
+		t.Errorf("Meta: Expected %q, got %v", "abc", val)
+	}
+	if val, ok := cas.Meta("tenant"); !ok || val != 42 {
+		t.Errorf("Meta: Expected %v, got %v", 42, val)
+	}
+
+	cas.SetMeta("trace", "def")
+	if val, _ := cas.Meta("trace"); val != "def" {
+		t.Errorf("Meta: Expected %q after replacing, got %v", "def", val)
+	}
+
+	cas.SetMeta("nil", nil)
+	if val, ok := cas.Meta("nil"); !ok || val != nil {
+		t.Error("Meta: A nil value should still be found!")
+	}
+
+	if _, ok := child.Meta("trace"); ok {
+		t.Error("Meta: Metadata should not be inherited by children!")
+	}
+
+	cas.Kill()
+	if val, ok := cas.Meta("trace"); !ok || val != "def" {
+		t.Error("Meta: Metadata should survive a Kill!")
+	}
+}
+
 func didExitBeforeTime(c *Cascade, d time.Duration) bool {
 	select {
 	case <-c.dead:
@@ -1405,43 +2713,40 @@ func didExitBeforeTime(c *Cascade, d time.Duration) bool {
 // For int values, negative means it doesn't matter
 func verifyCascadeEndState(t *testing.T, c *Cascade, hasParent bool, numChildren int, wantDead bool, numActions int, hasContext bool, numTrackedContexts int, hasError bool) {
 
-	if hasParent == (c.parent == nil) {
-		t.Errorf("Cascade Should Have Parent: %v, Cascade has Parent: %v", hasParent, c.parent != nil)
+	if hasParent == (c.Parent() == nil) {
+		t.Errorf("Cascade Should Have Parent: %v, Cascade has Parent: %v", hasParent, c.Parent() != nil)
 	}
 
-	c.muChildren.Lock()
+	state := c.Snapshot()
 	if numChildren >= 0 {
-		if len(c.children) != numChildren {
-			t.Errorf("Cascade should have %v Children, it has %v", numChildren, len(c.children))
+		if state.ChildCount != numChildren {
+			t.Errorf("Cascade should have %v Children, it has %v", numChildren, state.ChildCount)
 		}
 	}
-	c.muChildren.Unlock()
 
 	verifyDeadState(t, c, wantDead)
 
 	if numActions >= 0 {
-		if len(c.actions) != numActions {
-			t.Errorf("Cascade should have %v Actions, it has %v", numActions, len(c.actions))
+		if state.ActionCount != numActions {
+			t.Errorf("Cascade should have %v Actions, it has %v", numActions, state.ActionCount)
 		}
 	}
 
-	if hasContext == (c.ctx == nil) {
-		t.Errorf("Cascade Should Have Context: %v, Cascade has Context: %v", hasContext, c.ctx != nil)
+	if hasContext != state.HasContext {
+		t.Errorf("Cascade Should Have Context: %v, Cascade has Context: %v", hasContext, state.HasContext)
 	}
 
-	c.muCtx.Lock()
 	if numTrackedContexts >= 0 {
-		if len(c.trackedCtx) != numTrackedContexts {
-			t.Errorf("Cascade should have %v Tracked Contexts, it has %v", numTrackedContexts, len(c.trackedCtx))
+		if state.TrackedContexts != numTrackedContexts {
+			t.Errorf("Cascade should have %v Tracked Contexts, it has %v", numTrackedContexts, state.TrackedContexts)
 		}
 	}
-	c.muCtx.Unlock()
 
-	if hasError == (c.err == nil) {
-		if c.err != nil {
-			t.Errorf("Cascade Should Have Error: %v, Cascade has Error: %v", hasError, c.err)
+	if hasError == (state.Err == nil) {
+		if state.Err != nil {
+			t.Errorf("Cascade Should Have Error: %v, Cascade has Error: %v", hasError, state.Err)
 		} else {
-			t.Errorf("Cascade Should Have Error: %v, Cascade has Error: %v", hasError, c.err != nil)
+			t.Errorf("Cascade Should Have Error: %v, Cascade has Error: %v", hasError, state.Err != nil)
 		}
 
 	}
@@ -1493,3 +2798,203 @@ func verifyDeadState(t *testing.T, c *Cascade, wantDead bool) {
 		}
 	}
 }
+
+func TestCascade_RemoveAction(t *testing.T) {
+	cas := RootCascade()
+	ran := make([]int, 0)
+	kept := cas.DoOnKill(func() { ran = append(ran, 1) })
+	middle := cas.DoOnKill(func() { ran = append(ran, 2) })
+	cas.DoOnKill(func() { ran = append(ran, 3) })
+	first := cas.DoFirstOnKill(func() { ran = append(ran, 0) })
+
+	if !cas.RemoveAction(middle) || !cas.RemoveAction(first) {
+		t.Error("RemoveAction: Pending actions should be removed!")
+	}
+	if cas.RemoveAction(middle) || cas.RemoveAction(ActionID(0)) {
+		t.Error("RemoveAction: Unknown actions should not be removed!")
+	}
+
+	cas.Kill()
+
+	if len(ran) != 2 || ran[0] != 1 || ran[1] != 3 {
+		t.Errorf("RemoveAction: Expected actions [1 3] to run, got %v", ran)
+	}
+	if cas.RemoveAction(kept) {
+		t.Error("RemoveAction: Actions that have run should not be removed!")
+	}
+}
+
+func TestCascade_RemoveActionWhileRunning(t *testing.T) {
+	cas := RootCascade()
+	didLater := false
+	var later ActionID
+	removed := false
+	cas.DoOnKill(func() { removed = cas.RemoveAction(later) })
+	later = cas.DoOnKill(func() { didLater = true })
+
+	cas.Kill()
+
+	if !removed || didLater {
+		t.Error("RemoveActionWhileRunning: A later action should be removed by an earlier one!")
+	}
+}
+
+func TestCascade_RemoveActionCancelled(t *testing.T) {
+	cas := RootCascade()
+	id := cas.DoOnKill(func() {})
+	cas.Cancel()
+
+	if cas.RemoveAction(id) {
+		t.Error("RemoveActionCancelled: Skipped actions should not be removed!")
+	}
+}
+
+func TestCascade_DoOnKillOnce(t *testing.T) {
+	cas := RootCascade()
+	runs := 0
+	other := 0
+	for i := 0; i < 3; i++ {
+		if cas.DoOnKillOnce("cleanup", func() { runs++ }) != (i == 0) {
+			t.Error("DoOnKillOnce: Only the first registration of a key should be added!")
+		}
+	}
+	cas.DoOnKillOnce("other", func() { other++ })
+
+	cas.Kill()
+
+	if runs != 1 || other != 1 {
+		t.Errorf("DoOnKillOnce: Expected each key to run once, got %v and %v", runs, other)
+	}
+	if cas.DoOnKillOnce("cleanup", func() { runs++ }) || runs != 1 {
+		t.Error("DoOnKillOnce: Used keys should be ignored after the actions have run!")
+	}
+}
+
+func TestCascade_SetParallelActions(t *testing.T) {
+	cas := RootCascade(WithParallelActions())
+	ran := atomic.Int64{}
+	for i := 0; i < 50; i++ {
+		cas.DoOnKill(func() {
+			<-time.After(time.Second / 10)
+			ran.Add(1)
+		})
+	}
+	cas.DoOnKill(func() {
+		cas.DoOnKill(func() { ran.Add(1) }) // Added while running
+	})
+	slowDone := atomic.Bool{}
+	cas.DoAlways(func() {
+		<-time.After(time.Second / 2)
+		slowDone.Store(true)
+	})
+
+	start := time.Now()
+	go cas.Kill()
+	<-cas.Done()
+
+	if took := time.Since(start); took > time.Second*2 {
+		t.Errorf("SetParallelActions: Actions should run concurrently, took %v", took)
+	}
+	if count := ran.Load(); count != 51 {
+		t.Errorf("SetParallelActions: Expected 51 actions to run, got %v", count)
+	}
+	if !slowDone.Load() {
+		t.Error("SetParallelActions: Done should only close after the slowest action!")
+	}
+	if !cas.ChildCascade().isParallel() {
+		t.Error("SetParallelActions: Setting should be inherited!")
+	}
+}
+
+func TestCascade_AddError(t *testing.T) {
+	cas := RootCascade()
+	errs := []error{errors.New("first"), errors.New("second"), errors.New("third")}
+	cas.AddError(nil)
+	for _, err := range errs {
+		cas.AddError(err)
+	}
+	if !cas.Alive() {
+		t.Error("AddError: Adding errors should not kill the Cascade!")
+	}
+	for _, err := range errs {
+		if !errors.Is(cas.Error(), err) {
+			t.Errorf("AddError: Expected %v to be discoverable in %v", err, cas.Error())
+		}
+	}
+	cas.Kill()
+
+	cas = RootCascade()
+	cas.AddError(errs[0])
+	if cas.Error() != errs[0] {
+		t.Error("AddError: A single error should not be wrapped!")
+	}
+	cas.Kill()
+}
+
+func TestLink(t *testing.T) {
+	a, b, c := RootCascade(), RootCascade(), RootCascade()
+	Link(a, b, c)
+	unlinked := RootCascade()
+
+	b.Cancel()
+	for _, cas := range []*Cascade{a, c} {
+		if !cas.WaitDoneWithTimeout(time.Second) {
+			t.Fatal("Link: Linked Cascades should share the fate of the dying one!")
+		}
+		if cas.isCancelled() {
+			t.Error("Link: Linked Cascades should be killed!")
+		}
+	}
+	if !unlinked.Alive() {
+		t.Error("Link: Unlinked Cascades should not be affected!")
+	}
+	unlinked.Kill()
+
+	// Teardown through a parent counts, and a dead Cascade kills the others right away
+	root := RootCascade()
+	child := root.ChildCascade()
+	dead := RootCascade()
+	dead.Kill()
+	other := RootCascade()
+	Link(child, other)
+	root.Kill()
+	if !other.WaitDoneWithTimeout(time.Second) {
+		t.Error("Link: Cascades torn down by a parent should kill the others!")
+	}
+	last := RootCascade()
+	Link(dead, last)
+	if !last.WaitDoneWithTimeout(time.Second) {
+		t.Error("Link: A dead Cascade should kill the others right away!")
+	}
+}
+
+func TestCascade_HoldWithContext(t *testing.T) {
+	cas := RootCascade()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second/20)
+	defer cancel()
+	if err := cas.HoldWithContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("HoldWithContext: Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if !cas.Alive() {
+		t.Error("HoldWithContext: Context should not kill the Cascade!")
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- cas.HoldWithContext(context.Background())
+	}()
+	cas.Kill()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("HoldWithContext: Expected nil once the Cascade is dying, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("HoldWithContext: Dying Cascade should release the hold!")
+	}
+
+	cancel()
+	if err := cas.HoldWithContext(ctx); err != nil {
+		t.Errorf("HoldWithContext: Dying should win when both are done, got %v", err)
+	}
+}
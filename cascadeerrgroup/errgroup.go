@@ -0,0 +1,57 @@
+// Package cascadeerrgroup bridges `errgroup.Group` and Cascades so that code using errgroup can adopt Cascades
+// incrementally.
+//
+// It lives in its own module so that the core module does not depend on golang.org/x/sync.
+package cascadeerrgroup
+
+import (
+	"context"
+
+	"github.com/thedeltaflyer/cascade/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// FromErrGroup creates a new `RootCascade` linked to the provided Context (see `cascade.WithContext`) and an
+// `errgroup.Group` whose Context is derived from the Context of the Cascade. The two are tied together:
+//
+//   - When the Cascade dies, the Context of the group is cancelled with the error of the Cascade as its cause.
+//     The Context is cancelled once the Cascade is dead, after its actions have run.
+//   - When a task of the group returns an error, the Cascade is killed with that error (see `KillWithError`).
+//     `Wait` cancels the Context of the group even if no task failed, which leaves the Cascade alive. As a
+//     consequence a task returning `context.Canceled` itself does not kill the Cascade either.
+//
+// Use `ErrGroupContext` to get the Context that the tasks of the group should watch.
+//
+// On simultaneous failures each side keeps the error it saw first: the Cascade keeps the error it was killed
+// with and `Wait` on the group returns the first error returned by a task. An error from the group only kills
+// a Cascade that is still alive and never replaces an error that has already been set on it.
+//
+// The tasks of the group are not tracked by the Cascade (see `Mark`), so killing the Cascade does not wait for
+// them. Call `Wait` on the group for that.
+func FromErrGroup(ctx context.Context) (*cascade.Cascade, *errgroup.Group) {
+	cas, casCtx := cascade.WithContext(ctx)
+	group, groupCtx := errgroup.WithContext(casCtx)
+	cas.SetValue(groupKey{}, groupCtx)
+
+	// Not tracked so that it never shows up in the tracked count, it exits as soon as the Cascade starts dying
+	go func() {
+		select {
+		case <-cas.Dying():
+		case <-groupCtx.Done():
+			if err := context.Cause(groupCtx); err != context.Canceled {
+				_ = cas.KillWithError(err)
+			}
+		}
+	}()
+	return cas, group
+}
+
+// groupKey is the key under which the Context of the group is stored with `SetValue`
+type groupKey struct{}
+
+// ErrGroupContext returns the Context of the group created along with the Cascade by `FromErrGroup`, or `nil` if
+// the Cascade was not created by FromErrGroup.
+func ErrGroupContext(c *cascade.Cascade) context.Context {
+	ctx, _ := c.Value(groupKey{}).(context.Context)
+	return ctx
+}
@@ -0,0 +1,78 @@
+package cascadeerrgroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFromErrGroup_GroupError(t *testing.T) {
+	cas, group := FromErrGroup(context.Background())
+	failure := errors.New("failure")
+	group.Go(func() error { return failure })
+	group.Go(func() error {
+		<-ErrGroupContext(cas).Done()
+		return nil
+	})
+
+	if !cas.WaitDoneWithTimeout(time.Second) {
+		t.Fatal("FromErrGroup: Cascade should die when a task fails!")
+	}
+	if err := cas.Error(); err != failure {
+		t.Errorf("FromErrGroup: Expected the error of the task, got %v", err)
+	}
+	if err := group.Wait(); err != failure {
+		t.Errorf("FromErrGroup: Expected the group to return the error of the task, got %v", err)
+	}
+}
+
+func TestFromErrGroup_CascadeKilled(t *testing.T) {
+	cas, group := FromErrGroup(context.Background())
+	groupCtx := ErrGroupContext(cas)
+	group.Go(func() error {
+		<-groupCtx.Done()
+		return groupCtx.Err()
+	})
+
+	failure := errors.New("failure")
+	if err := cas.KillWithError(failure); err != nil {
+		t.Fatal(err)
+	}
+	if err := group.Wait(); err != context.Canceled {
+		t.Errorf("FromErrGroup: Expected the tasks to be cancelled, got %v", err)
+	}
+	if err := context.Cause(groupCtx); err != failure {
+		t.Errorf("FromErrGroup: Expected the error of the Cascade as the cause, got %v", err)
+	}
+	if err := cas.Error(); err != failure {
+		t.Errorf("FromErrGroup: Cascade should keep its own error, got %v", err)
+	}
+}
+
+func TestFromErrGroup_Wait(t *testing.T) {
+	cas, group := FromErrGroup(context.Background())
+	group.Go(func() error { return nil })
+
+	if err := group.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	<-time.After(time.Second / 20)
+	if !cas.Alive() {
+		t.Error("FromErrGroup: A group without errors should not kill the Cascade!")
+	}
+	cas.Kill()
+	if cas.TrackedCount() != 0 {
+		t.Error("FromErrGroup: Watcher should not be tracked!")
+	}
+}
+
+func TestFromErrGroup_ParentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cas, _ := FromErrGroup(ctx)
+	cancel()
+
+	if !cas.WaitDoneWithTimeout(time.Second) {
+		t.Error("FromErrGroup: Cascade should die with its parent Context!")
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/thedeltaflyer/cascade/cascadeerrgroup
+
+go 1.20
+
+require (
+	github.com/thedeltaflyer/cascade/v2 v2.0.0-00010101000000-000000000000
+	golang.org/x/sync v0.7.0
+)
+
+replace github.com/thedeltaflyer/cascade/v2 => ../
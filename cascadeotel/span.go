@@ -0,0 +1,59 @@
+// Package cascadeotel traces the lifetime of Cascades with OpenTelemetry spans.
+//
+// It lives in its own module so that the core module does not depend on OpenTelemetry.
+package cascadeotel
+
+import (
+	"context"
+	"sync"
+
+	"github.com/thedeltaflyer/cascade/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanKey is the key under which the span of a Cascade is stored with `SetValue`
+type spanKey struct{}
+
+// StartSpan starts a span with the provided name that covers the lifetime of the Cascade: it is ended when the
+// Cascade is done (see `Done`). If an error has been set on the Cascade by then (see `Cause`) it is recorded on
+// the span and the status of the span is set to `codes.Error`.
+//
+// The span is stored on the Cascade, so calling StartSpan for a descendant makes its span a child of the span of
+// the closest ancestor that has one. A span in the provided context takes precedence, otherwise the context
+// only supplies values. The returned context carries the new span.
+//
+// StartSpan is usually called right after the Cascade is created. A Cascade that is already done gets a span
+// that is ended right away.
+func StartSpan(ctx context.Context, tracer trace.Tracer, c *cascade.Cascade, name string,
+	opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		if parent, ok := c.Value(spanKey{}).(trace.Span); ok {
+			ctx = trace.ContextWithSpan(ctx, parent)
+		}
+	}
+	ctx, span := tracer.Start(ctx, name, opts...)
+	c.SetValue(spanKey{}, span)
+
+	once := sync.Once{}
+	end := func() {
+		once.Do(func() {
+			if err := c.Cause(); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		})
+	}
+	c.OnStateChange(func(state cascade.State) {
+		if state == cascade.StateDone {
+			end()
+		}
+	})
+	select {
+	case <-c.Done(): // Done before the hook was registered
+		end()
+	default:
+	}
+	return ctx, span
+}
@@ -0,0 +1,112 @@
+package cascadeotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/thedeltaflyer/cascade/v2"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Creates a tracer that exports every ended span to the returned exporter right away
+func newTracer() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return provider, exporter
+}
+
+func TestStartSpan(t *testing.T) {
+	provider, exporter := newTracer()
+	tracer := provider.Tracer("cascadeotel")
+
+	root := cascade.RootCascade()
+	StartSpan(context.Background(), tracer, root, "root")
+	child := root.ChildCascade()
+	StartSpan(context.Background(), tracer, child, "child")
+	grandchild := child.ChildCascade()
+	StartSpan(context.Background(), tracer, grandchild, "grandchild")
+	unspanned := root.ChildCascade().ChildCascade() // Skips a level
+	StartSpan(context.Background(), tracer, unspanned, "unspanned")
+
+	if len(exporter.GetSpans()) != 0 {
+		t.Error("StartSpan: Spans should not end while the Cascades are alive!")
+	}
+
+	failure := errors.New("failure")
+	_ = child.KillWithError(failure)
+	root.Kill()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 4 {
+		t.Fatalf("StartSpan: Expected 4 spans, got %v", len(spans))
+	}
+	byName := make(map[string]tracetest.SpanStub)
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+	rootCtx := byName["root"].SpanContext
+	if byName["root"].Parent.IsValid() {
+		t.Error("StartSpan: Root span should not have a parent!")
+	}
+	if byName["child"].Parent.SpanID() != rootCtx.SpanID() {
+		t.Error("StartSpan: Child span should be a child of the root span!")
+	}
+	if byName["grandchild"].Parent.SpanID() != byName["child"].SpanContext.SpanID() {
+		t.Error("StartSpan: Grandchild span should be a child of the child span!")
+	}
+	if byName["unspanned"].Parent.SpanID() != rootCtx.SpanID() {
+		t.Error("StartSpan: Span should be a child of the closest ancestor with a span!")
+	}
+	for _, span := range spans {
+		if span.SpanContext.TraceID() != rootCtx.TraceID() {
+			t.Error("StartSpan: Every span should share the trace of the root span!")
+		}
+	}
+
+	if status := byName["child"].Status; status.Code != codes.Error || status.Description != "failure" {
+		t.Errorf("StartSpan: Expected an error status on the child span, got %v", status)
+	}
+	if events := byName["child"].Events; len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("StartSpan: Expected the error to be recorded on the child span, got %v", events)
+	}
+	if status := byName["root"].Status; status.Code != codes.Unset || len(byName["root"].Events) != 0 {
+		t.Error("StartSpan: A Cascade without an error should not record one!")
+	}
+	if !byName["grandchild"].EndTime.Before(byName["root"].EndTime) {
+		t.Error("StartSpan: Descendant spans should end before their ancestors!")
+	}
+}
+
+func TestStartSpanContextParent(t *testing.T) {
+	provider, exporter := newTracer()
+	tracer := provider.Tracer("cascadeotel")
+
+	ctx, outer := tracer.Start(context.Background(), "outer")
+	root := cascade.RootCascade()
+	spanCtx, span := StartSpan(ctx, tracer, root, "root")
+	if !span.SpanContext().Equal(trace.SpanContextFromContext(spanCtx)) {
+		t.Error("StartSpanContextParent: Returned context should carry the new span!")
+	}
+	root.Cancel()
+	outer.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 || spans[0].Parent.SpanID() != outer.SpanContext().SpanID() {
+		t.Error("StartSpanContextParent: Span in the context should be the parent!")
+	}
+}
+
+func TestStartSpanDone(t *testing.T) {
+	provider, exporter := newTracer()
+	root := cascade.RootCascade()
+	root.Kill()
+
+	StartSpan(context.Background(), provider.Tracer("cascadeotel"), root, "root")
+	if len(exporter.GetSpans()) != 1 {
+		t.Error("StartSpanDone: Span of a done Cascade should end right away!")
+	}
+}
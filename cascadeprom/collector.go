@@ -0,0 +1,79 @@
+// Package cascadeprom exposes the state of a Cascade tree as Prometheus metrics.
+//
+// It lives in its own module so that the core module does not depend on the Prometheus client.
+package cascadeprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/thedeltaflyer/cascade/v2"
+)
+
+// Collector implements `prometheus.Collector` for the tree of a Cascade. Every `Collect` walks the tree as it
+// is at that moment, using only the locked accessors of the Cascade.
+//
+// Cascades are identified by their `Path`, so the gauges of Cascades that share a path (for example unnamed
+// siblings) are summed together.
+type Collector struct {
+	root     *cascade.Cascade
+	tracked  *prometheus.Desc
+	children *prometheus.Desc
+	kills    *prometheus.Desc
+	cancels  *prometheus.Desc
+}
+
+// NewCollector creates a Collector for the tree of the provided Cascade, which is usually a `RootCascade`.
+// Only the Cascade and its descendants are reported, the kill and cancel counters always cover the whole tree
+// (see `KillCount`).
+func NewCollector(root *cascade.Cascade) *Collector {
+	return &Collector{
+		root: root,
+		tracked: prometheus.NewDesc("cascade_tracked_goroutines",
+			"Number of goroutines tracked by the Cascade.", []string{"path"}, nil),
+		children: prometheus.NewDesc("cascade_children",
+			"Number of children of the Cascade.", []string{"path"}, nil),
+		kills: prometheus.NewDesc("cascade_kills_total",
+			"Number of Cascades in the tree that have been killed.", nil, nil),
+		cancels: prometheus.NewDesc("cascade_cancels_total",
+			"Number of Cascades in the tree that have been cancelled.", nil, nil),
+	}
+}
+
+// Describe implements `prometheus.Collector`.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tracked
+	ch <- c.children
+	ch <- c.kills
+	ch <- c.cancels
+}
+
+// Collect implements `prometheus.Collector`.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	type counts struct{ tracked, children int }
+	paths := make([]string, 0)
+	byPath := make(map[string]*counts)
+	var visit func(node *cascade.Cascade)
+	visit = func(node *cascade.Cascade) {
+		children := node.Children()
+		path := node.Path()
+		entry, ok := byPath[path]
+		if !ok {
+			entry = &counts{}
+			byPath[path] = entry
+			paths = append(paths, path)
+		}
+		entry.tracked += node.TrackedCount()
+		entry.children += len(children)
+		for _, child := range children {
+			visit(child)
+		}
+	}
+	visit(c.root)
+
+	for _, path := range paths {
+		entry := byPath[path]
+		ch <- prometheus.MustNewConstMetric(c.tracked, prometheus.GaugeValue, float64(entry.tracked), path)
+		ch <- prometheus.MustNewConstMetric(c.children, prometheus.GaugeValue, float64(entry.children), path)
+	}
+	ch <- prometheus.MustNewConstMetric(c.kills, prometheus.CounterValue, float64(c.root.KillCount()))
+	ch <- prometheus.MustNewConstMetric(c.cancels, prometheus.CounterValue, float64(c.root.CancelCount()))
+}
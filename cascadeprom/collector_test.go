@@ -0,0 +1,73 @@
+package cascadeprom
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/thedeltaflyer/cascade/v2"
+)
+
+// Gathers the registry and returns the value of every sample by metric name and path label
+func scrape(t *testing.T, reg *prometheus.Registry) map[string]map[string]float64 {
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples := make(map[string]map[string]float64)
+	for _, family := range families {
+		values := make(map[string]float64)
+		for _, metric := range family.GetMetric() {
+			path := ""
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "path" {
+					path = label.GetValue()
+				}
+			}
+			if metric.GetGauge() != nil {
+				values[path] = metric.GetGauge().GetValue()
+			} else {
+				values[path] = metric.GetCounter().GetValue()
+			}
+		}
+		samples[family.GetName()] = values
+	}
+	return samples
+}
+
+func TestCollector(t *testing.T) {
+	root := cascade.RootCascade()
+	root.SetName("root")
+	workers := root.ChildCascade()
+	workers.SetName("workers")
+	workers.ChildCascade()
+	workers.ChildCascade().Cancel()
+	root.Mark()
+	workers.Mark()
+	workers.Mark()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewCollector(root))
+	samples := scrape(t, reg)
+
+	if v := samples["cascade_tracked_goroutines"]["root"]; v != 1 {
+		t.Errorf("Collector: Expected 1 tracked goroutine on root, got %v", v)
+	}
+	if v := samples["cascade_tracked_goroutines"]["root/workers"]; v != 2 {
+		t.Errorf("Collector: Expected 2 tracked goroutines on workers, got %v", v)
+	}
+	if v := samples["cascade_children"]["root/workers"]; v != 1 {
+		t.Errorf("Collector: Expected 1 child on workers, got %v", v)
+	}
+	if v := samples["cascade_cancels_total"][""]; v != 1 {
+		t.Errorf("Collector: Expected 1 cancel, got %v", v)
+	}
+
+	root.UnMark()
+	workers.UnMark()
+	workers.UnMark()
+	root.Kill()
+	samples = scrape(t, reg)
+	if v := samples["cascade_kills_total"][""]; v != 3 {
+		t.Errorf("Collector: Expected 3 kills, got %v", v)
+	}
+}
@@ -0,0 +1,129 @@
+package cascade
+
+import (
+	"time"
+)
+
+// SetActionTimeout limits how long each kill, cancel or always action (see `DoOnKill`, `DoOnCancel` and
+// `DoAlways`) may run. If an action is still running after the provided duration, it is left running in the
+// background, the remaining actions of the same kind are dropped and an `*ActionTimeoutError` is set as the
+// error of the Cascade (if none is set yet). The teardown then carries on, so a single hanging cleanup can't
+// keep the Cascade from being done.
+//
+// With parallel actions (see `SetParallelActions`) the duration applies to every batch of actions that runs
+// concurrently. Critical actions (see `DoCritical`) are never bounded. A duration of 0 (the default) lets
+// actions run for as long as they take.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetActionTimeout(d time.Duration) {
+	c.muConfig.Lock()
+	c.actionTimeout = d
+	c.muConfig.Unlock()
+}
+
+// SetErrorJoining enables or disables error joining. With error joining enabled, `KillWithError` and
+// `CancelWithError` join the provided error onto an error that has already been set (see `AddError`) instead
+// of dropping it and returning an `*AlreadySetError`.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetErrorJoining(enabled bool) {
+	c.muConfig.Lock()
+	c.joinErrors = enabled
+	c.muConfig.Unlock()
+}
+
+// SetKillTimeout limits how long `Close` waits for the Cascade to become dead, see `KillWithTimeout`. A
+// duration of 0 (the default) makes Close wait for as long as it takes.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetKillTimeout(d time.Duration) {
+	c.muConfig.Lock()
+	c.killTimeout = d
+	c.muConfig.Unlock()
+}
+
+// SetMaxTrackedContexts limits the number of Contexts returned by `Context` (and its variants) that the Cascade
+// tracks at once. Every Context derived from a distinct parent is tracked until it is done or the Cascade dies,
+// so a long-lived Cascade that hands out a Context per request would otherwise grow without bound.
+//
+// When the limit is exceeded, the oldest tracked Contexts are cancelled and evicted. A later call to `Context`
+// with the same parent returns a new Context. A limit of 0 (the default) disables the bound.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetMaxTrackedContexts(n int) {
+	c.muConfig.Lock()
+	c.maxCtx = n
+	c.muConfig.Unlock()
+}
+
+// SkippedActions returns the number of `DoOnKill` actions that were skipped because the Cascade was
+// cancelled instead of killed.
+func (c *Cascade) SkippedActions() int {
+	c.muActions.Lock()
+	defer c.muActions.Unlock()
+	return c.skipped
+}
+
+func (c *Cascade) preStopOnCancel() bool {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.preOnCancel
+}
+
+func (c *Cascade) maxTrackedContexts() int {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.maxCtx
+}
+
+func (c *Cascade) getActionTimeout() time.Duration {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.actionTimeout
+}
+
+func (c *Cascade) isErrorJoining() bool {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.joinErrors
+}
+
+func (c *Cascade) getKillTimeout() time.Duration {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.killTimeout
+}
+
+func (c *Cascade) isParallel() bool {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.parallel
+}
+
+func (c *Cascade) isSequenced() bool {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.sequenced
+}
+
+// Copies the inheritable configuration of the Cascade onto a new child.
+func (c *Cascade) copyConfig(child *Cascade) {
+	c.muConfig.RLock()
+	child.logger = c.logger
+	child.strict = c.strict
+	child.verbose = c.verbose
+	child.slowChild = c.slowChild
+	child.watchdog = c.watchdog
+	child.leakAfter = c.leakAfter
+	child.leakPanic = c.leakPanic
+	child.leakTrack.Store(c.leakAfter > 0)
+	child.preOnCancel = c.preOnCancel
+	child.sequenced = c.sequenced
+	child.parallel = c.parallel
+	child.actionTimeout = c.actionTimeout
+	child.joinErrors = c.joinErrors
+	child.panicPolicy = c.panicPolicy
+	child.killTimeout = c.killTimeout
+	child.maxCtx = c.maxCtx
+	c.muConfig.RUnlock()
+}
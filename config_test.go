@@ -0,0 +1,123 @@
+package cascade
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCascade_SetActionTimeout(t *testing.T) {
+	cas := RootCascade(WithActionTimeout(time.Second / 10))
+	block := make(chan struct{})
+	defer close(block)
+	didFirst := false
+	didLast := false
+	cas.DoOnKill(func() { didFirst = true })
+	cas.DoOnKill(func() { <-block })
+	cas.DoOnKill(func() { didLast = true })
+	didAlways := false
+	cas.DoAlways(func() { didAlways = true })
+
+	go cas.Kill()
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Fatal("SetActionTimeout: Done should close despite the blocking action!")
+	}
+
+	if !didFirst || didLast {
+		t.Error("SetActionTimeout: Only the actions after the blocking one should be skipped!")
+	}
+	if !didAlways {
+		t.Error("SetActionTimeout: Other kinds of actions should still run!")
+	}
+	var timeoutErr *ActionTimeoutError
+	if !errors.As(cas.Error(), &timeoutErr) || timeoutErr.Skipped != 1 || timeoutErr.Timeout != time.Second/10 {
+		t.Errorf("SetActionTimeout: Expected an *ActionTimeoutError, got %v", cas.Error())
+	}
+}
+
+func TestCascade_SetActionTimeoutParallel(t *testing.T) {
+	cas := RootCascade(WithActionTimeout(time.Second/10), WithParallelActions())
+	block := make(chan struct{})
+	defer close(block)
+	didOther := atomic.Bool{}
+	cas.DoOnKill(func() { <-block })
+	cas.DoOnKill(func() { didOther.Store(true) })
+
+	go cas.Kill()
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Fatal("SetActionTimeoutParallel: Done should close despite the blocking action!")
+	}
+	if !didOther.Load() {
+		t.Error("SetActionTimeoutParallel: Actions of the same batch should still run!")
+	}
+	var timeoutErr *ActionTimeoutError
+	if !errors.As(cas.Error(), &timeoutErr) {
+		t.Errorf("SetActionTimeoutParallel: Expected an *ActionTimeoutError, got %v", cas.Error())
+	}
+}
+
+func TestCascade_SetErrorJoining(t *testing.T) {
+	cas := RootCascade(WithErrorJoining())
+	child := cas.ChildCascade()
+	errs := []error{errors.New("first"), errors.New("second"), errors.New("third")}
+	child.AddError(errs[0])
+	if err := child.KillWithError(errs[1]); err != nil {
+		t.Errorf("SetErrorJoining: Expected the error to be joined, got %v", err)
+	}
+	if err := child.CancelWithError(errs[2]); err != nil {
+		t.Errorf("SetErrorJoining: Expected the error to be joined, got %v", err)
+	}
+	for _, err := range errs {
+		if !errors.Is(child.Error(), err) {
+			t.Errorf("SetErrorJoining: Expected %v to be discoverable in %v", err, child.Error())
+		}
+	}
+
+	cas.SetErrorJoining(false)
+	cas.AddError(errs[0])
+	if err := cas.CancelWithError(errs[1]); err == nil || !cas.Alive() {
+		t.Error("SetErrorJoining: Errors should be rejected once joining is disabled!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_SetMaxTrackedContexts(t *testing.T) {
+	cas := RootCascade(WithMaxTrackedContexts(10))
+	type key struct{}
+	derived := make([]context.Context, 0, 100)
+	for i := 0; i < 100; i++ {
+		derived = append(derived, cas.Context(context.WithValue(context.Background(), key{}, i)))
+		cas.muCtx.Lock()
+		tracked := len(cas.trackedCtx)
+		cas.muCtx.Unlock()
+		if tracked > 10 {
+			t.Fatalf("SetMaxTrackedContexts: Expected at most 10 tracked contexts, got %v", tracked)
+		}
+	}
+
+	for i, ctx := range derived {
+		evicted := ctx.Err() != nil
+		if evicted != (i < 90) {
+			t.Errorf("SetMaxTrackedContexts: Expected only the oldest contexts to be evicted, context %v evicted=%v", i, evicted)
+		}
+	}
+	if !cas.Alive() {
+		t.Error("SetMaxTrackedContexts: Evicting contexts should not kill the Cascade!")
+	}
+
+	cas.Kill()
+	for _, ctx := range derived[90:] {
+		if ctx.Err() == nil {
+			t.Error("SetMaxTrackedContexts: Remaining contexts should be cancelled by the kill!")
+		}
+	}
+	if cas.ChildCascade().maxTrackedContexts() != 10 {
+		t.Error("SetMaxTrackedContexts: Setting should be inherited!")
+	}
+}
@@ -2,6 +2,8 @@ package cascade
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
 // WithContext links a Context to a new `RootCascade`. When the provided Context is Cancelled,
@@ -9,6 +11,9 @@ import (
 //
 // The function also returns a child context that will be cancelled when the Cascade is killed or cancelled.
 // (Regardless of the state of the parent Context)
+//
+// The Context is watched by a goroutine that `Wait` waits for but that is not counted by `TrackedCount`, it
+// exits as soon as the Cascade is killed or cancelled so `Wait` never returns while it is still running.
 func WithContext(ctx context.Context) (*Cascade, context.Context) {
 	cas := RootCascade()
 	return cas, cas.linkWithContext(ctx)
@@ -24,31 +29,118 @@ func (c *Cascade) WithContext(ctx context.Context) (*Cascade, context.Context) {
 	return cas, cas.linkWithContext(ctx)
 }
 
+// WithTimeout links a Context that is done after the provided duration to a new `RootCascade` (see
+// `WithContext`), so the Cascade is killed once the timeout elapses or the parent Context is cancelled.
+//
+// The returned Context is cancelled when the Cascade is killed or cancelled and has the same deadline.
+// The timer of the timeout is released as soon as the Cascade is done.
+func WithTimeout(parent context.Context, d time.Duration) (*Cascade, context.Context) {
+	return WithDeadline(parent, time.Now().Add(d))
+}
+
+// WithDeadline links a Context that is done at the provided time to a new `RootCascade` (see
+// `WithContext`), so the Cascade is killed once the deadline passes or the parent Context is cancelled.
+//
+// The returned Context is cancelled when the Cascade is killed or cancelled and has the same deadline.
+// The timer of the deadline is released as soon as the Cascade is done.
+func WithDeadline(parent context.Context, deadline time.Time) (*Cascade, context.Context) {
+	cas := RootCascade()
+	return cas, cas.linkWithDeadline(parent, deadline)
+}
+
+// WithTimeout links a Context that is done after the provided duration to a new child Cascade. See the
+// `WithTimeout` function.
+func (c *Cascade) WithTimeout(parent context.Context, d time.Duration) (*Cascade, context.Context) {
+	return c.WithDeadline(parent, time.Now().Add(d))
+}
+
+// WithDeadline links a Context that is done at the provided time to a new child Cascade. See the
+// `WithDeadline` function.
+func (c *Cascade) WithDeadline(parent context.Context, deadline time.Time) (*Cascade, context.Context) {
+	cas := c.ChildCascade()
+	return cas, cas.linkWithDeadline(parent, deadline)
+}
+
+func (c *Cascade) linkWithDeadline(parent context.Context, deadline time.Time) context.Context {
+	ctx, cancel := context.WithDeadline(parent, deadline)
+	c.muCtx.Lock()
+	c.ctxCancels = append(c.ctxCancels, cancel)
+	c.muCtx.Unlock()
+	return c.linkWithContext(ctx)
+}
+
+// WithContexts links each of the provided Contexts to its own new child Cascade (just like `WithContext`) and
+// returns the children in the same order as the Contexts. Each child is killed when its own Context is
+// cancelled, independently of the others.
+//
+// The Contexts returned by `Context(nil)` on each child are cancelled when that child is killed or cancelled.
+func (c *Cascade) WithContexts(ctxs ...context.Context) []*Cascade {
+	children := make([]*Cascade, 0, len(ctxs))
+	for _, ctx := range ctxs {
+		child, _ := c.WithContext(ctx)
+		children = append(children, child)
+	}
+	return children
+}
+
 func (c *Cascade) linkWithContext(ctx context.Context) context.Context {
+	c.killOnDone(ctx)
+	c.muCtx.Lock()
+	c.ctx = ctx
+	c.muCtx.Unlock()
+	tracked, cancel := context.WithCancelCause(ctx)
+	c.linkTrackedContext(ctx, tracked, cancel)
+	return tracked
+}
+
+// Kills the Cascade once the provided Context is done. The watcher is counted with markWatcher, so `Wait` and
+// `Dead` cover it without it showing up in TrackedCount. It exits once dying is closed, which every teardown
+// path does before waiting on tracked goroutines. A Cascade that is never killed (with a Context that is
+// never done) keeps its watcher for as long as it lives.
+func (c *Cascade) killOnDone(ctx context.Context) {
 	if ctx.Done() != nil {
+		c.markWatcher()
 		go func() {
 			select {
 			case <-c.Dying():
+				c.unmarkWatcher()
 			case <-ctx.Done():
+				if c.Alive() {
+					c.setErrorIfUnset(context.Cause(ctx)) // See Cause
+				}
+				c.unmarkWatcher() // Kill waits for watchers, so this one has to be released first
 				c.Kill()
 			}
 		}()
 	}
-	c.muCtx.Lock()
-	c.ctx = ctx
-	c.muCtx.Unlock()
-	tracked, cancel := context.WithCancel(ctx)
-	c.linkTrackedContext(ctx, tracked, cancel)
-	return tracked
+}
+
+// Cause returns the reason the Cascade died: the error set on it (see `Error`) or, if it was killed because a
+// Context linked with `WithContext` (or one of its variants) ended, the cause of that Context (see
+// `context.Cause`), such as `context.DeadlineExceeded`. The Context's cause is stored as the error of the
+// Cascade, so it never replaces an error that has already been set.
+//
+// `nil` is returned while the Cascade is alive or if it was killed or cancelled without an error.
+func (c *Cascade) Cause() error {
+	return c.explicitError()
 }
 
 // Context returns a `context.Context` that will be cancelled when the Cascade that it was
-// generated from is killed or cancelled.
+// generated from is killed or cancelled. If the Cascade has an error set (see `Error`) when the
+// Context is cancelled, that error is used as the Context's cause (see `context.Cause`).
 //
 // If a Context is provided, it will be used as the parent for the new Context. If `nil` is passed,
 // either the Cascade's parent Context (if it exists) or `context.Background()` will
 // be used as the parent.
+//
+// If the Cascade is already dead, an already cancelled Context is returned right away.
+//
+// See `InheritContextValues` for having the returned Context also carry the values of the
+// Cascade's parent Context when a different parent is provided.
 func (c *Cascade) Context(ctx context.Context) context.Context {
+	if c.IsDead() {
+		return c.cancelledContext(ctx)
+	}
 	if ctx == nil {
 		cc, ret := func() (context.Context, bool) {
 			c.muCtx.Lock()
@@ -86,20 +178,240 @@ func (c *Cascade) Context(ctx context.Context) context.Context {
 		}
 	}
 
-	tracked, cancel := context.WithCancel(ctx)
+	tracked, cancel := context.WithCancelCause(c.valueParent(ctx))
 	c.linkTrackedContext(ctx, tracked, cancel)
 	return tracked
 }
 
-func (c *Cascade) linkTrackedContext(ctx context.Context, child interface{}, cancel func()) {
+// Returns an already cancelled Context without tracking it, for a Cascade that is dead
+func (c *Cascade) cancelledContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		c.muCtx.Lock()
+		ctx = c.ctx
+		c.muCtx.Unlock()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+	}
+	cancelled, cancel := context.WithCancelCause(c.valueParent(ctx))
+	cancel(c.explicitError())
+	return cancelled
+}
+
+// ContextWithError returns a `context.Context` just like `Context` except that once it has been cancelled
+// because the Cascade was killed or cancelled with an error set (see `Error`), its `Err` method returns that
+// error instead of `context.Canceled`.
+//
+// Contexts that are cancelled for any other reason, such as their parent being cancelled, report their
+// error as usual.
+func (c *Cascade) ContextWithError(ctx context.Context) context.Context {
+	return errorContext{c.Context(ctx), c}
+}
+
+// errorContext is a Context that behaves exactly like its embedded Context except that it reports the
+// error of the Cascade that cancelled it from Err.
+type errorContext struct {
+	context.Context
+	cascade *Cascade
+}
+
+func (e errorContext) Err() error {
+	err := e.Context.Err()
+	if err == nil || !e.cascade.IsDead() {
+		return err
+	}
+	if casErr := e.cascade.explicitError(); casErr != nil && errors.Is(context.Cause(e.Context), casErr) {
+		return casErr
+	}
+	return err
+}
+
+// GoWithContext runs the provided function as a tracked goroutine of a new child Cascade (just like `Go`) and
+// also passes it a Context derived from the provided parent with `Context`, for calling code that expects a
+// Context rather than a Cascade.
+//
+// Unlike a Context returned by `Context`, which is only cancelled once the Cascade is dead, this Context is
+// cancelled as soon as the child starts dying (with the error of the child as its cause, see `Cause`), so the
+// function can use it as its exit condition. It is released as soon as the function returns. If `nil` is
+// passed, the parent Context is picked just like with `Context(nil)`.
+func (c *Cascade) GoWithContext(parent context.Context, f func(*Cascade, context.Context)) *Cascade {
+	child := c.ChildCascade()
+	child.launch(func() {
+		child.Wrap(func(cc *Cascade) {
+			ctx, cancel := context.WithCancelCause(cc.Context(parent))
+			defer cancel(nil)
+			returned := make(chan struct{})
+			defer close(returned)
+			go func() {
+				select {
+				case <-cc.Dying():
+					cancel(cc.Cause())
+				case <-returned:
+				}
+			}()
+			f(cc, ctx)
+		})
+	})
+	return child
+}
+
+// ContextWithCancel returns a new `context.Context` that is cancelled when the Cascade is killed or cancelled
+// (just like `Context`) along with a function that cancels only that Context and stops tracking it, without
+// affecting the Cascade or any other Context.
+//
+// Unlike `Context`, every call returns a new Context even for the same parent, so cancelling it never affects
+// other users of the parent. Calling the cancel function more than once, or after the Cascade has died, does
+// nothing. If `nil` is passed, the Cascade's parent Context (or `context.Background()`) is used as the parent.
+func (c *Cascade) ContextWithCancel(parent context.Context) (context.Context, context.CancelFunc) {
+	if c.IsDead() {
+		return c.cancelledContext(parent), func() {}
+	}
+	if parent == nil {
+		c.muCtx.Lock()
+		parent = c.ctx
+		c.muCtx.Unlock()
+		if parent == nil {
+			parent = context.Background()
+		}
+	}
+	tracked, cancel := context.WithCancelCause(c.valueParent(parent))
+	key := &cancelableContext{tracked} // Never handed out, so Context can't return this Context for a parent
+	c.linkTrackedContext(key, tracked, cancel)
+	return tracked, func() {
+		c.muCtx.Lock()
+		delete(c.trackedCtx, key) // trackedCtx is nil once the Cascade is dead, deleting from it is a no-op
+		c.muCtx.Unlock()
+		cancel(nil)
+	}
+}
+
+// cancelableContext is the key under which a Context returned by ContextWithCancel is tracked
+type cancelableContext struct {
+	context.Context
+}
+
+// ContextBidirectional returns a `context.Context` just like `Context` except that the link goes both ways:
+// the returned Context is cancelled when the Cascade is killed or cancelled AND the Cascade is killed when the
+// returned Context is cancelled through the provided parent.
+//
+// This is meant for the case where the returned Context represents the single request that drives the
+// Cascade. With `Context`, cancelling the parent only cancels the returned Context and leaves the Cascade
+// running.
+//
+// A parent that is already cancelled kills the Cascade right away, with the cause of the parent as its error
+// (see `Cause`).
+//
+// If `nil` is passed, the Cascade's parent Context is used just like with `Context`. Since that Context
+// already kills the Cascade, this is the same as calling `Context(nil)`.
+func (c *Cascade) ContextBidirectional(parent context.Context) context.Context {
+	ctx := c.Context(parent)
+	if parent == nil {
+		return ctx
+	}
+	c.muCtx.Lock()
+	tracked, ok := c.trackedCtx[parent]
+	watch := ok && !tracked.bidirectional
+	if watch {
+		tracked.bidirectional = true
+		c.trackedCtx[parent] = tracked
+	} else if !ok && parent.Err() != nil {
+		watch = true // Already cancelled, so it was never tracked, the watcher kills the Cascade right away
+	}
+	c.muCtx.Unlock()
+	if watch {
+		c.killOnDone(parent)
+	}
+	return ctx
+}
+
+// CancelContextsWhere cancels every Context returned by `Context` for which the provided function returns
+// `true`, without killing or cancelling the Cascade. Cancelled Contexts stop being tracked so a later call to
+// `Context` with the same parent returns a new Context.
+//
+// This is useful for aborting a batch of work that was handed Contexts from the Cascade while keeping the
+// Cascade alive for the next batch.
+//
+// The provided function is called without any of the Cascade's locks held.
+func (c *Cascade) CancelContextsWhere(pred func(context.Context) bool) {
+	c.muCtx.Lock()
+	candidates := make(map[context.Context]trackedContext, len(c.trackedCtx))
+	for parent, tracked := range c.trackedCtx {
+		candidates[parent] = tracked
+	}
+	c.muCtx.Unlock()
+
+	for parent, tracked := range candidates {
+		if !pred(tracked.context) {
+			delete(candidates, parent)
+		}
+	}
+
+	c.muCtx.Lock()
+	for parent, tracked := range candidates {
+		// Only remove the entry if it hasn't been replaced in the meantime.
+		if current, ok := c.trackedCtx[parent]; ok && current.context == tracked.context {
+			delete(c.trackedCtx, parent)
+		}
+	}
+	c.muCtx.Unlock()
+
+	for _, tracked := range candidates {
+		tracked.cancel(nil)
+	}
+}
+
+// InheritContextValues controls whether Contexts returned by `Context` inherit the values of the
+// Cascade's parent Context (the one provided to `WithContext`) in addition to the values of the
+// provided parent.
+//
+// When enabled, a value lookup on the returned Context is first made against the provided parent and,
+// if the key is not found there, against the Cascade's parent Context. Cancellation and deadlines are
+// still only taken from the provided parent. This has no effect if the Cascade has no parent Context or
+// if the provided parent is the Cascade's parent Context.
+//
+// Note: Contexts that have already been returned by `Context` are not affected by this setting.
+func (c *Cascade) InheritContextValues(inherit bool) {
+	c.muCtx.Lock()
+	c.inheritCtx = inherit
+	c.muCtx.Unlock()
+}
+
+// valueParent returns the Context that should be used as the parent for a new tracked Context
+// created from ctx.
+func (c *Cascade) valueParent(ctx context.Context) context.Context {
+	c.muCtx.Lock()
+	defer c.muCtx.Unlock()
+	if !c.inheritCtx || c.ctx == nil || c.ctx == ctx {
+		return ctx
+	}
+	return mergedContext{ctx, c.ctx}
+}
+
+// mergedContext is a Context that behaves exactly like its embedded Context except that value
+// lookups fall back to a second Context.
+type mergedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (m mergedContext) Value(key interface{}) interface{} {
+	if val := m.Context.Value(key); val != nil {
+		return val
+	}
+	return m.values.Value(key)
+}
+
+func (c *Cascade) linkTrackedContext(ctx context.Context, child interface{}, cancel context.CancelCauseFunc) {
 	// Check to make sure that the cascade hasn't already died!
 	if c.IsDead() {
-		cancel()
+		cancel(c.explicitError())
 		return
 	}
 
+	max := c.maxTrackedContexts()
 	c.muCtx.Lock()
-	c.trackedCtx[ctx] = trackedContext{child.(context.Context), cancel}
+	c.lastCtx++
+	c.trackedCtx[ctx] = trackedContext{child.(context.Context), cancel, false, c.lastCtx}
 
 	// Double-check that all the other tracked contexts are still ok
 	for ctx, tracked := range c.trackedCtx {
@@ -109,5 +421,20 @@ func (c *Cascade) linkTrackedContext(ctx context.Context, child interface{}, can
 		default:
 		}
 	}
+	for max > 0 && len(c.trackedCtx) > max {
+		c.evictOldestContext()
+	}
 	c.muCtx.Unlock()
 }
+
+// Cancels and stops tracking the oldest tracked context. MUST be called with muCtx held.
+func (c *Cascade) evictOldestContext() {
+	var oldest context.Context
+	for parent, tracked := range c.trackedCtx {
+		if oldest == nil || tracked.order < c.trackedCtx[oldest].order {
+			oldest = parent
+		}
+	}
+	c.trackedCtx[oldest].cancel(nil)
+	delete(c.trackedCtx, oldest)
+}
@@ -2,6 +2,8 @@ package cascade
 
 import (
 	"context"
+	"errors"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -61,7 +63,7 @@ func TestWithContext(t *testing.T) {
 		t.Error("_WithContext: Context2 was not Cancelled!")
 	}
 
-	verifyCascadeEndState(t, cas1, false, 0, true, 0, true, 0, false)
+	verifyCascadeEndState(t, cas1, false, 0, true, 0, true, 0, true) // Killed by the Context, which sets its cause as the error
 
 }
 
@@ -117,7 +119,7 @@ func TestWithContextKillCascade(t *testing.T) {
 
 	cancel()
 
-	verifyCascadeEndState(t, cas1, false, 0, true, 0, true, 0, false)
+	verifyCascadeEndState(t, cas1, false, 0, true, 0, true, 0, true)
 
 }
 
@@ -158,7 +160,7 @@ func TestCascade_WithContext(t *testing.T) {
 		t.Error("WithContext: Context1 was not Cancelled!")
 	}
 
-	verifyCascadeEndState(t, cas1, true, 0, true, 0, true, 0, false)
+	verifyCascadeEndState(t, cas1, true, 0, true, 0, true, 0, true)
 
 	go cas.Kill()
 
@@ -238,7 +240,7 @@ func TestCascade_ContextCancelFromContext(t *testing.T) {
 		t.Error("ContextCancelFromContext: Cas got stuck!")
 	}
 
-	verifyCascadeEndState(t, cas, false, 0, true, 0, true, 0, false)
+	verifyCascadeEndState(t, cas, false, 0, true, 0, true, 0, true)
 }
 
 func TestCascade_ContextCancelFromCascade(t *testing.T) {
@@ -284,7 +286,7 @@ func TestCascade_ContextFromKilledCascade(t *testing.T) {
 	cas.muCtx.Lock()
 	ctx1Cancel := cas.trackedCtx[ctx].cancel
 	cas.muCtx.Unlock()
-	ctx1Cancel()
+	ctx1Cancel(nil)
 
 	_ = cas.Context(ctx)
 	_ = cas.Context(ctxAlt)
@@ -294,8 +296,8 @@ func TestCascade_ContextFromKilledCascade(t *testing.T) {
 	ctx1Cancel = cas.trackedCtx[ctx].cancel
 	ctx2Cancel := cas.trackedCtx[ctxAlt].cancel
 	cas.muCtx.Unlock()
-	ctx1Cancel()
-	ctx2Cancel()
+	ctx1Cancel(nil)
+	ctx2Cancel(nil)
 
 	_ = cas.Context(ctxAlt)
 
@@ -312,3 +314,563 @@ func TestCascade_ContextFromKilledCascade(t *testing.T) {
 		t.Error("ContextCancelFromKilledCascade: Context2 did not Cancel!")
 	}
 }
+
+func TestCascade_InheritContextValues(t *testing.T) {
+	base := context.WithValue(context.TODO(), contextKey("base"), "base")
+	base = context.WithValue(base, contextKey("shared"), "base")
+	other, cancel := context.WithCancel(context.WithValue(context.TODO(), contextKey("shared"), "other"))
+	defer cancel()
+
+	cas, _ := WithContext(base)
+
+	ctx1 := cas.Context(other)
+	if ctx1.Value(contextKey("base")) != nil {
+		t.Error("InheritContextValues: Inherited a value without being enabled!")
+	}
+
+	cas.InheritContextValues(true)
+
+	alt := context.WithValue(other, contextKey("alt"), "alt")
+	ctx2 := cas.Context(alt)
+	if val := ctx2.Value(contextKey("base")); val != "base" {
+		t.Errorf("InheritContextValues: Expected inherited value %q, got %v", "base", val)
+	}
+	if val := ctx2.Value(contextKey("shared")); val != "other" {
+		t.Errorf("InheritContextValues: Expected provided parent's value %q, got %v", "other", val)
+	}
+	if val := ctx2.Value(contextKey("alt")); val != "alt" {
+		t.Errorf("InheritContextValues: Expected provided parent's value %q, got %v", "alt", val)
+	}
+	if val := ctx2.Value(contextKey("missing")); val != nil {
+		t.Errorf("InheritContextValues: Expected no value for a missing key, got %v", val)
+	}
+
+	verifyCascadeEndState(t, cas, false, 0, false, 0, true, 3, false)
+
+	cancel()
+	select {
+	case <-ctx2.Done():
+	case <-time.After(time.Second / 2):
+		t.Error("InheritContextValues: Context was not cancelled by its provided parent!")
+	}
+	if cas.IsDead() {
+		t.Error("InheritContextValues: Cancelling the provided parent killed the Cascade!")
+	}
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("InheritContextValues: Cas got stuck!")
+	}
+}
+
+func TestCascade_CancelContextsWhere(t *testing.T) {
+	cas := RootCascade()
+	parents := []context.Context{
+		context.WithValue(context.TODO(), contextKey("worker"), 1),
+		context.WithValue(context.TODO(), contextKey("worker"), 2),
+		context.WithValue(context.TODO(), contextKey("worker"), 3),
+	}
+	ctxs := make([]context.Context, len(parents))
+	for i, parent := range parents {
+		ctxs[i] = cas.Context(parent)
+	}
+
+	cas.CancelContextsWhere(func(ctx context.Context) bool {
+		return ctx.Value(contextKey("worker")) != 2
+	})
+
+	for i, ctx := range ctxs {
+		select {
+		case <-ctx.Done():
+			if i == 1 {
+				t.Errorf("CancelContextsWhere: Context %v should not have been cancelled!", i)
+			}
+		default:
+			if i != 1 {
+				t.Errorf("CancelContextsWhere: Context %v should have been cancelled!", i)
+			}
+		}
+	}
+
+	if cas.IsDead() {
+		t.Error("CancelContextsWhere: Cascade was killed!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, false, 0, false, 1, false)
+
+	if ctx := cas.Context(parents[0]); ctx == ctxs[0] {
+		t.Error("CancelContextsWhere: Cancelled Context was returned again!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, false, 0, false, 2, false)
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("CancelContextsWhere: Cas got stuck!")
+	}
+	select {
+	case <-ctxs[1].Done():
+	case <-time.After(time.Second / 2):
+		t.Error("CancelContextsWhere: Remaining Context was not cancelled by Kill!")
+	}
+}
+
+func TestCascade_ContextBidirectional(t *testing.T) {
+	cas := RootCascade()
+	parent, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	ctx := cas.ContextBidirectional(parent)
+	if again := cas.ContextBidirectional(parent); again != ctx {
+		t.Error("ContextBidirectional: Did not return the tracked Context for the same parent!")
+	}
+
+	cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second / 2):
+		t.Error("ContextBidirectional: Context was not cancelled by its parent!")
+	}
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("ContextBidirectional: Cancelling the Context did not kill the Cascade!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, true)
+}
+
+func TestCascade_ContextBidirectionalCancelledParent(t *testing.T) {
+	cas := RootCascade()
+	cause := errors.New("request aborted")
+	parent, cancel := context.WithCancelCause(context.TODO())
+	cancel(cause)
+
+	ctx := cas.ContextBidirectional(parent)
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Fatal("ContextBidirectionalCancelledParent: A cancelled parent did not kill the Cascade!")
+	}
+	if ctx.Err() == nil {
+		t.Error("ContextBidirectionalCancelledParent: Context should be cancelled!")
+	}
+	if cas.Cause() != cause {
+		t.Errorf("ContextBidirectionalCancelledParent: Expected %v, got %v", cause, cas.Cause())
+	}
+}
+
+func TestCascade_ContextBidirectionalFromCascade(t *testing.T) {
+	cas := RootCascade()
+	parent, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	ctx := cas.ContextBidirectional(parent)
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("ContextBidirectionalFromCascade: Cas got stuck!")
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second / 2):
+		t.Error("ContextBidirectionalFromCascade: Context was not cancelled by the Cascade!")
+	}
+	select {
+	case <-parent.Done():
+		t.Error("ContextBidirectionalFromCascade: Parent Context was cancelled!")
+	default:
+	}
+}
+
+func TestCascade_ContextWithError(t *testing.T) {
+	cas := RootCascade()
+	ctx := cas.ContextWithError(nil)
+	if ctx.Err() != nil {
+		t.Error("ContextWithError: Context was cancelled before the Cascade died!")
+	}
+
+	err := errors.New("kill")
+	cas.KillWithError(err)
+	<-ctx.Done()
+	if ctx.Err() != err {
+		t.Errorf("ContextWithError: Expected %v, got %v", err, ctx.Err())
+	}
+
+	cas = RootCascade()
+	ctx = cas.ContextWithError(nil)
+	cas.Kill()
+	if ctx.Err() != context.Canceled {
+		t.Errorf("ContextWithError: Expected %v without an error set, got %v", context.Canceled, ctx.Err())
+	}
+
+	cas = RootCascade()
+	parent, parentCancel := context.WithCancel(context.Background())
+	ctx = cas.ContextWithError(parent)
+	parentCancel()
+	cas.KillWithError(err)
+	if ctx.Err() != context.Canceled {
+		t.Errorf("ContextWithError: Expected the parent's error, got %v", ctx.Err())
+	}
+}
+
+func TestCascade_ContextFromDeadCascade(t *testing.T) {
+	cas := RootCascade()
+	err := errors.New("dead")
+	cas.KillWithError(err)
+
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	before := runtime.NumGoroutine()
+	ctxs := []context.Context{cas.Context(nil), cas.Context(parent), cas.ContextBidirectional(parent)}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("ContextFromDeadCascade: Expected no new goroutines, %v were started", after-before)
+	}
+	for _, ctx := range ctxs {
+		select {
+		case <-ctx.Done():
+		default:
+			t.Error("ContextFromDeadCascade: Context was not cancelled!")
+		}
+		if context.Cause(ctx) != err {
+			t.Errorf("ContextFromDeadCascade: Expected cause %v, got %v", err, context.Cause(ctx))
+		}
+	}
+	if cas.ctx != nil || cas.trackedCtx != nil {
+		t.Error("ContextFromDeadCascade: Context state was touched!")
+	}
+}
+
+func TestCascade_WithContexts(t *testing.T) {
+	cas := RootCascade()
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	children := cas.WithContexts(ctx1, ctx2)
+	if len(children) != 2 || children[0].ctx != ctx1 || children[1].ctx != ctx2 {
+		t.Fatal("WithContexts: Children do not match the Contexts!")
+	}
+
+	cancel1()
+	if !didExitBeforeTime(children[0], time.Second/2) {
+		t.Error("WithContexts: Child was not killed by its Context!")
+	}
+	if children[1].IsDead() {
+		t.Error("WithContexts: Child was killed by another Context!")
+	}
+
+	cas.Kill()
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+	verifyCascadeEndState(t, children[1], true, 0, true, 0, true, 0, false)
+}
+
+func TestWithTimeout(t *testing.T) {
+	start := time.Now()
+	cas, ctx := WithTimeout(context.Background(), time.Second/10)
+	deadline, ok := ctx.Deadline()
+	if !ok || deadline.Before(start.Add(time.Second/10)) || deadline.After(time.Now().Add(time.Second/10)) {
+		t.Errorf("WithTimeout: Unexpected deadline %v", deadline)
+	}
+	select {
+	case <-cas.Done():
+	case <-time.After(time.Second):
+		t.Fatal("WithTimeout: Cascade was not killed at the deadline!")
+	}
+	if time.Since(start) < time.Second/10 {
+		t.Error("WithTimeout: Cascade was killed before the deadline!")
+	}
+	<-ctx.Done()
+}
+
+func TestWithDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	cas := RootCascade()
+	child, ctx := cas.WithDeadline(context.Background(), deadline)
+	if got, ok := ctx.Deadline(); !ok || !got.Equal(deadline) {
+		t.Errorf("WithDeadline: Expected deadline %v, got %v", deadline, got)
+	}
+
+	cas.Kill()
+	<-ctx.Done()
+	if child.ctx.Err() != context.Canceled {
+		t.Error("WithDeadline: Deadline Context was not released after the kill!")
+	}
+	verifyCascadeEndState(t, child, true, 0, true, 0, true, 0, false)
+	if child.ctxCancels != nil {
+		t.Error("WithDeadline: Cancel functions were not cleaned up!")
+	}
+}
+
+func TestCascade_AsContext(t *testing.T) {
+	parent := context.WithValue(context.Background(), contextKey("key"), "val")
+	parent, cancel := context.WithDeadline(parent, time.Now().Add(time.Hour))
+	defer cancel()
+	cas, _ := WithContext(parent)
+
+	var ctx context.Context = cas
+	if ctx.Value(contextKey("key")) != "val" {
+		t.Error("AsContext: Value was not found!")
+	}
+	if deadline, ok := ctx.Deadline(); !ok || deadline.IsZero() {
+		t.Error("AsContext: Deadline was not found!")
+	}
+	derived, derivedCancel := context.WithCancel(ctx)
+	defer derivedCancel()
+	if ctx.Err() != nil || derived.Err() != nil {
+		t.Error("AsContext: Context was cancelled before the Cascade was done!")
+	}
+
+	cas.Kill()
+	select {
+	case <-derived.Done():
+	case <-time.After(time.Second):
+		t.Fatal("AsContext: Cancellation did not propagate!")
+	}
+	if ctx.Err() != context.Canceled {
+		t.Errorf("AsContext: Expected %v, got %v", context.Canceled, ctx.Err())
+	}
+
+	cas = RootCascade()
+	err := errors.New("kill")
+	cas.KillWithError(err)
+	if cas.Err() != err {
+		t.Errorf("AsContext: Expected %v, got %v", err, cas.Err())
+	}
+	if _, ok := cas.Deadline(); ok || cas.Value(contextKey("key")) != nil {
+		t.Error("AsContext: A Cascade without a Context should have no deadline or values!")
+	}
+}
+
+func TestWithContext_Watcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, stop := range []func(*Cascade){(*Cascade).Kill, (*Cascade).Cancel} {
+		cas, _ := WithContext(ctx)
+		if cas.TrackedCount() != 0 || cas.watchers.Load() != 1 {
+			t.Errorf("WithContext: Expected the watcher to be counted as a watcher, got %d tracked", cas.TrackedCount())
+		}
+		stop(cas)
+		if !didExitBeforeTime(cas, time.Second) || cas.watchers.Load() != 0 {
+			t.Error("WithContext: Watcher should exit when the Cascade dies!")
+		}
+	}
+
+	// The watcher of a Context that ends first kills the Cascade without blocking itself
+	cas, _ := WithContext(ctx)
+	cancel()
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Error("WithContext: Cancelled Context should kill the Cascade!")
+	}
+	cas.WaitDone()
+	if cas.watchers.Load() != 0 {
+		t.Error("WithContext: Watcher should not be counted after the kill!")
+	}
+}
+
+func TestWithContext_WatcherNotTracked(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cas, _ := WithContext(ctx)
+	defer cas.Kill()
+
+	if !cas.Quiesce(time.Second) {
+		t.Error("WithContext: Quiesce should not wait for the watcher!")
+	}
+	cas.Unquiesce()
+	if !cas.WaitForMarksBelow(1, time.Second) {
+		t.Error("WithContext: WaitForMarksBelow should not count the watcher!")
+	}
+	if state := cas.Snapshot(); state.Tracked != 0 || cas.PeakTracked() != 0 {
+		t.Errorf("WithContext: Watcher should not be reported as tracked, got %d", state.Tracked)
+	}
+}
+
+func TestCascade_ValueFallback(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("request"), "42")
+	cas, _ := WithContext(ctx)
+	child := cas.ChildCascade()
+	grandchild := child.ChildCascade()
+
+	if val := grandchild.Value(contextKey("request")); val != "42" {
+		t.Errorf("Value: Expected the value of the parent's Context, got %v", val)
+	}
+	if val := grandchild.Value(contextKey("missing")); val != nil {
+		t.Errorf("Value: Expected nil for a missing key, got %v", val)
+	}
+
+	// A linked Context takes precedence over the parent
+	linked, _ := child.WithContext(context.WithValue(context.Background(), contextKey("request"), "7"))
+	if val := linked.Value(contextKey("request")); val != "7" {
+		t.Errorf("Value: Expected the value of the linked Context, got %v", val)
+	}
+	if RootCascade().Value(contextKey("request")) != nil {
+		t.Error("Value: Root without a Context should have no values!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_SetValue(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	cas.SetValue(contextKey("tenant"), "acme")
+	cas.SetValue(contextKey("region"), "eu")
+	child.SetValue(contextKey("region"), "us")
+
+	if val := child.Value(contextKey("tenant")); val != "acme" {
+		t.Errorf("SetValue: Expected the parent's value, got %v", val)
+	}
+	if val := child.Value(contextKey("region")); val != "us" {
+		t.Errorf("SetValue: Expected the child's override, got %v", val)
+	}
+	if val := cas.Value(contextKey("region")); val != "eu" {
+		t.Errorf("SetValue: Override should not affect the parent, got %v", val)
+	}
+	if val := child.Value(contextKey("missing")); val != nil {
+		t.Errorf("SetValue: Expected nil for a missing key, got %v", val)
+	}
+
+	// Stored values take precedence over the linked Context
+	linked, _ := WithContext(context.WithValue(context.Background(), contextKey("tenant"), "ctx"))
+	linked.SetValue(contextKey("tenant"), "stored")
+	if val := linked.Value(contextKey("tenant")); val != "stored" {
+		t.Errorf("SetValue: Expected the stored value, got %v", val)
+	}
+	cas.Kill()
+	linked.Kill()
+}
+
+func TestCascade_Cause(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	cas, _ := WithContext(ctx)
+	if cas.Cause() != nil {
+		t.Error("Cause: Alive Cascade should have no cause!")
+	}
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Fatal("Cause: Timeout did not kill the Cascade!")
+	}
+	cas.WaitDone()
+	if !errors.Is(cas.Cause(), context.DeadlineExceeded) {
+		t.Errorf("Cause: Expected %v, got %v", context.DeadlineExceeded, cas.Cause())
+	}
+
+	// An explicit error is never replaced
+	ctx, cancel = context.WithCancel(context.Background())
+	cas, _ = WithContext(ctx)
+	explicit := errors.New("explicit")
+	cas.setErrorIfUnset(explicit)
+	cancel()
+	cas.WaitDone()
+	if cas.Cause() != explicit {
+		t.Errorf("Cause: Expected %v, got %v", explicit, cas.Cause())
+	}
+
+	cas = RootCascade()
+	cas.Kill()
+	if cas.Cause() != nil {
+		t.Error("Cause: Plain kill should have no cause!")
+	}
+}
+
+func TestCascade_GoWithContext(t *testing.T) {
+	cas := RootCascade()
+	type key struct{}
+	parent := context.WithValue(context.Background(), key{}, "value")
+	started := make(chan context.Context, 1)
+	exited := make(chan error, 1)
+	child := cas.GoWithContext(parent, func(c *Cascade, ctx context.Context) {
+		started <- ctx
+		<-ctx.Done()
+		exited <- ctx.Err()
+	})
+
+	ctx := <-started
+	if ctx.Value(key{}) != "value" {
+		t.Error("GoWithContext: Context should be derived from the provided parent!")
+	}
+	if child.TrackedCount() == 0 {
+		t.Error("GoWithContext: Function should be tracked by the child!")
+	}
+
+	child.Kill()
+	select {
+	case err := <-exited:
+		if err != context.Canceled {
+			t.Errorf("GoWithContext: Expected %v, got %v", context.Canceled, err)
+		}
+	default:
+		t.Error("GoWithContext: Kill should wait for the function to exit!")
+	}
+	if cas.IsDead() {
+		t.Error("GoWithContext: Killing the child should not kill the parent!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_GoWithContextReturn(t *testing.T) {
+	cas := RootCascade()
+	returned := make(chan context.Context, 1)
+	child := cas.GoWithContext(nil, func(c *Cascade, ctx context.Context) {
+		returned <- ctx
+	})
+
+	ctx := <-returned
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Error("GoWithContext: Context should be released when the function returns!")
+	}
+	if child.IsDead() {
+		t.Error("GoWithContext: Returning should not kill the child!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_GoWithContextCause(t *testing.T) {
+	cas := RootCascade()
+	cause := make(chan error, 1)
+	child := cas.GoWithContext(nil, func(c *Cascade, ctx context.Context) {
+		<-ctx.Done()
+		cause <- context.Cause(ctx)
+	})
+
+	failure := errors.New("failure")
+	if err := child.KillWithError(failure); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-cause; err != failure {
+		t.Errorf("GoWithContextCause: Expected %v, got %v", failure, err)
+	}
+	cas.Kill()
+}
+
+func TestCascade_ContextWithCancel(t *testing.T) {
+	cas := RootCascade()
+	parent := context.Background()
+	shared := cas.Context(parent)
+	ctx1, cancel1 := cas.ContextWithCancel(parent)
+	ctx2, cancel2 := cas.ContextWithCancel(parent)
+	if ctx1 == ctx2 || ctx1 == shared {
+		t.Error("ContextWithCancel: Every call should return a new Context!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, false, 0, false, 3, false)
+
+	cancel1()
+	cancel1() // Double cancel is a no-op
+	if ctx1.Err() == nil {
+		t.Error("ContextWithCancel: Context should be cancelled!")
+	}
+	if ctx2.Err() != nil || shared.Err() != nil || !cas.Alive() {
+		t.Error("ContextWithCancel: Cancelling should only affect its own Context!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, false, 0, false, 2, false)
+	if cas.Context(ctx1) == ctx1 {
+		t.Error("ContextWithCancel: Context should never be handed out as a tracked Context!")
+	}
+
+	cas.Kill()
+	if ctx2.Err() == nil {
+		t.Error("ContextWithCancel: Context should be cancelled when the Cascade dies!")
+	}
+	cancel2() // Cancel after death is a no-op
+
+	ctx3, cancel3 := cas.ContextWithCancel(nil)
+	if ctx3.Err() == nil {
+		t.Error("ContextWithCancel: Context of a dead Cascade should already be cancelled!")
+	}
+	cancel3()
+}
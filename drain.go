@@ -0,0 +1,48 @@
+package cascade
+
+// Drain stops the Cascade and all of its descendants from accepting new work, waits for the work that is
+// already running to finish on its own and then kills the Cascade (see `Kill`), running its actions as usual.
+//
+// While draining, `ChildCascade` returns children that are already cancelled, so `Go` and its variants do not
+// run their function (`GoResult` reports `ErrDraining`), and `Mark` rejects new goroutines by returning
+// `false`, so `Wrap` and the `WrapInLoop` variants do not run theirs either. Unlike `Kill`, the Cascade is not
+// signalled as dying until the work is done, so tracked goroutines are not asked to exit early. The work is
+// done once no goroutine is tracked anywhere in the tree.
+//
+// Note: This function blocks until the tree is done. Functions that wait for the Cascade to be dying (for
+// example with `Hold`) never finish on their own and keep Drain waiting, they need to be killed separately.
+func (c *Cascade) Drain() {
+	c.startDraining()
+	c.waitQuiet()
+	c.Kill()
+}
+
+// Draining returns `true` once `Drain` has been called on the Cascade or one of its ancestors.
+func (c *Cascade) Draining() bool {
+	return c.draining.Load()
+}
+
+// Flags the Cascade and its current descendants as draining. The flag is set under the write lock of
+// muTracked so that every Mark either completes before it or is rejected.
+func (c *Cascade) startDraining() {
+	c.muTracked.Lock()
+	c.draining.Store(true)
+	c.muTracked.Unlock()
+	for _, child := range c.childSnapshot() {
+		child.startDraining()
+	}
+}
+
+// Waits until nothing is tracked in the subtree or the Cascade starts dying. Marks are rejected while
+// draining, so a Cascade that reached zero stays there and each one only has to be waited on once. Internal
+// watchers (see markWatcher) only exit once the Cascade is dying, so they are not counted as work.
+func (c *Cascade) waitQuiet() {
+	c.muTracked.Lock()
+	for c.tracked.Load() > 0 && c.Alive() {
+		c.condTracked.Wait() // Broadcast by UnMark and by the teardown once the Cascade is dying
+	}
+	c.muTracked.Unlock()
+	for _, child := range c.childSnapshot() {
+		child.waitQuiet()
+	}
+}
@@ -0,0 +1,98 @@
+package cascade
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCascade_Drain(t *testing.T) {
+	cas := RootCascade()
+	release := make(chan struct{})
+	var completed, rejectedRan atomic.Bool
+	started := make(chan struct{})
+	inFlight := cas.Go(func(cc *Cascade) {
+		close(started)
+		<-release
+		completed.Store(true)
+	})
+	<-started // Work that has not started yet by the time Drain is called is rejected
+
+	drained := make(chan struct{})
+	go func() {
+		cas.Drain()
+		close(drained)
+	}()
+	for !cas.Draining() {
+		time.Sleep(time.Millisecond)
+	}
+
+	rejected := cas.Go(func(cc *Cascade) { rejectedRan.Store(true) })
+	if rejected.Alive() {
+		t.Error("Drain: New children should be cancelled!")
+	}
+	if !inFlight.Draining() {
+		t.Error("Drain: Existing children should be draining!")
+	}
+	_, _, errs := GoResult(cas, func(cc *Cascade) (int, error) { return 1, nil })
+	if err := <-errs; err != ErrDraining {
+		t.Errorf("Drain: Expected %v, got %v", ErrDraining, err)
+	}
+	if inFlight.ShouldExit() {
+		t.Error("Drain: In-flight work should not be asked to exit!")
+	}
+	if cas.Mark() || inFlight.Mark() || inFlight.TrackedCount() != 2 { // Tracked by launch and Wrap
+		t.Error("Drain: Mark should be rejected while draining!")
+	}
+	wrapped := false
+	cas.Wrap(func(cc *Cascade) { wrapped = true })
+	if wrapped {
+		t.Error("Drain: Wrap should not run its function while draining!")
+	}
+
+	select {
+	case <-drained:
+		t.Fatal("Drain: Should wait for in-flight work!")
+	case <-time.After(30 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-drained:
+	case <-time.After(time.Second):
+		t.Fatal("Drain: Got stuck after the in-flight work finished!")
+	}
+	if !completed.Load() || rejectedRan.Load() {
+		t.Error("Drain: Only the in-flight work should have run!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+// Returns `true` if Drain returned before the duration elapsed
+func drainedBeforeTime(c *Cascade, d time.Duration) bool {
+	drained := make(chan struct{})
+	go func() {
+		c.Drain()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+
+func TestCascade_DrainWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cas, _ := WithContext(ctx)
+	child := cas.ChildCascade()
+	child.KillWhen(RootCascade())
+	cas.Go(func(cc *Cascade) { <-time.After(10 * time.Millisecond) })
+
+	if !drainedBeforeTime(cas, time.Second) {
+		t.Fatal("Drain: Should not wait for the watchers of the Cascade!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, true, 0, false)
+}
@@ -0,0 +1,87 @@
+package cascade
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrKilled is returned by `Error` for a Cascade that was killed without an error being set.
+	ErrKilled = errors.New("cascade: killed")
+	// ErrCancelled is returned by `Error` for a Cascade that was cancelled without an error being set.
+	ErrCancelled = errors.New("cascade: cancelled")
+	// ErrPoolDying is returned by `Pool.Submit` once the Pool has started shutting down.
+	ErrPoolDying = errors.New("cascade: pool is dying")
+	// ErrDraining is returned by `GoResult` when the function was not started because the Cascade is draining
+	// (see `Drain`).
+	ErrDraining = errors.New("cascade: draining")
+	// ErrParentDead is returned by `ChildCascadeSafe` when the parent has been killed or cancelled.
+	ErrParentDead = errors.New("cascade: parent is dead")
+	// ErrAlreadyDead is returned by `KillWithCause` when the Cascade had already been killed or cancelled.
+	ErrAlreadyDead = errors.New("cascade: already dead")
+	// ErrTooManyRestarts is the error a supervised Cascade is killed with once its `RestartPolicy` is exhausted.
+	ErrTooManyRestarts = errors.New("cascade: too many restarts")
+)
+
+// AlreadySetError is returned when an error could not be set on a Cascade because another error had already
+// been set first (for example by a concurrent call to `KillWithError`).
+//
+// The error that was set first is available as `Existing` and through `errors.Unwrap`, so a caller that lost
+// the race can still act on the error that won it. Dropped is the error that could not be set.
+type AlreadySetError struct {
+	Existing error
+	Dropped  error
+}
+
+func (e *AlreadySetError) Error() string {
+	if e.Dropped != nil {
+		return "cascade: error already set, dropped: " + e.Dropped.Error()
+	}
+	return "cascade: error already set"
+}
+
+func (e *AlreadySetError) Unwrap() error {
+	return e.Existing
+}
+
+// NodeError pairs an error with the `Path` of the Cascade it was set on, see `KillCollect`.
+type NodeError struct {
+	Path string
+	Err  error
+}
+
+func (e *NodeError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
+
+// KillTimeoutError is returned by `KillWithTimeout` when the Cascade did not become dead in time.
+//
+// Tracked is the number of goroutines that were still tracked by the Cascade when the timeout elapsed. It is
+// 0 if the kill was still waiting on a child, `Tree` shows which part of the tree is stuck.
+type KillTimeoutError struct {
+	Path    string
+	Tracked int
+	Timeout time.Duration
+}
+
+func (e *KillTimeoutError) Error() string {
+	return fmt.Sprintf("cascade: %s is not dead after %s, %d goroutine(s) still tracked", e.Path, e.Timeout, e.Tracked)
+}
+
+// ActionTimeoutError is set as the error of a Cascade when one of its actions ran for longer than the action
+// timeout (see `SetActionTimeout`). Skipped is the number of actions that were dropped because of it.
+type ActionTimeoutError struct {
+	Path    string
+	Skipped int
+	Timeout time.Duration
+}
+
+func (e *ActionTimeoutError) Error() string {
+	return fmt.Sprintf("cascade: an action of %s ran for longer than %s, %d action(s) skipped", e.Path, e.Timeout,
+		e.Skipped)
+}
@@ -1,3 +1,3 @@
-module github.com/thedeltaflyer/cascade
+module github.com/thedeltaflyer/cascade/v2
 
-go 1.12
+go 1.20
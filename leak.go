@@ -0,0 +1,115 @@
+package cascade
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// markSite is an outstanding `Mark` recorded while leak detection is enabled (see `SetLeakDetection`).
+type markSite struct {
+	owner string // Function that called Mark, with closures folded into the function that encloses them
+	site  string // File and line of the first caller outside of this package
+}
+
+// Directory of the source files of this package, frames from it are skipped when looking for a call site
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// Records the call site of a Mark, must be called directly by Mark
+func (c *Cascade) recordMark() {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)]) // Skip Callers, recordMark and Mark
+	var mark markSite
+	for {
+		frame, more := frames.Next()
+		if mark.owner == "" {
+			mark.owner = enclosingFunc(frame.Function)
+		}
+		mark.site = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		if !more || !isPackageFrame(frame) {
+			break
+		}
+	}
+	c.muMarks.Lock()
+	c.marks = append(c.marks, mark)
+	c.muMarks.Unlock()
+}
+
+// Drops the most recent mark made by the function calling UnMark (or the most recent mark if that function did
+// not make any), must be called directly by UnMark
+func (c *Cascade) releaseMark() {
+	pcs := make([]uintptr, 1)
+	frame, _ := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)]).Next() // Skip Callers, releaseMark and UnMark
+	owner := enclosingFunc(frame.Function)
+	c.muMarks.Lock()
+	defer c.muMarks.Unlock()
+	if len(c.marks) == 0 {
+		return
+	}
+	i := len(c.marks) - 1
+	for j := i; j >= 0; j-- {
+		if c.marks[j].owner == owner {
+			i = j
+			break
+		}
+	}
+	c.marks = append(c.marks[:i], c.marks[i+1:]...)
+}
+
+// Lists the call sites of the outstanding marks in the order they were first made, with a count for sites
+// that have more than one
+func (c *Cascade) markSites() string {
+	c.muMarks.Lock()
+	defer c.muMarks.Unlock()
+	var order []string
+	counts := make(map[string]int)
+	for _, mark := range c.marks {
+		if counts[mark.site] == 0 {
+			order = append(order, mark.site)
+		}
+		counts[mark.site]++
+	}
+	for i, site := range order {
+		if counts[site] > 1 {
+			order[i] = fmt.Sprintf("%s (x%d)", site, counts[site])
+		}
+	}
+	return strings.Join(order, ", ")
+}
+
+// Returns `true` for frames of this package other than its tests
+func isPackageFrame(frame runtime.Frame) bool {
+	return filepath.Dir(frame.File) == packageDir && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// Strips the closure suffixes (such as ".func1", ".gowrap2" or ".1") from a function name, so that a closure
+// is attributed to the function that encloses it.
+func enclosingFunc(name string) string {
+	for {
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 || !isClosureName(name[i+1:]) {
+			return name
+		}
+		name = name[:i]
+	}
+}
+
+// Returns `true` for the names the compiler gives to closures and the goroutines started by `go` statements
+func isClosureName(name string) bool {
+	for _, prefix := range []string{"func", "gowrap"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
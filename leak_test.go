@@ -0,0 +1,49 @@
+package cascade
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnclosingFunc(t *testing.T) {
+	for name, want := range map[string]string{
+		"github.com/thedeltaflyer/cascade/v2.(*Cascade).launch":       "github.com/thedeltaflyer/cascade/v2.(*Cascade).launch",
+		"github.com/thedeltaflyer/cascade/v2.(*Cascade).launch.func1": "github.com/thedeltaflyer/cascade/v2.(*Cascade).launch",
+		"github.com/thedeltaflyer/cascade/v2.TestCascade_Go.func2.1":  "github.com/thedeltaflyer/cascade/v2.TestCascade_Go",
+		"github.com/thedeltaflyer/cascade/v2.(*Pool).Submit.gowrap1":  "github.com/thedeltaflyer/cascade/v2.(*Pool).Submit",
+		"github.com/thedeltaflyer/cascade/v2.(*Cascade).function":     "github.com/thedeltaflyer/cascade/v2.(*Cascade).function",
+		"main.main": "main.main",
+	} {
+		if got := enclosingFunc(name); got != want {
+			t.Errorf("enclosingFunc: Expected %q for %q, got %q", want, name, got)
+		}
+	}
+}
+
+func TestCascade_LeakDetectionSites(t *testing.T) {
+	cas := RootCascade(WithLeakDetection(time.Hour, false))
+	release := make(chan struct{})
+	child := cas.Go(func(c *Cascade) { <-release })
+
+	sites := child.markSites()
+	if !strings.Contains(sites, "leak_test.go:") || strings.Contains(sites, ",") {
+		t.Errorf("LeakDetection: Expected the site of Go to be listed, got %q", sites)
+	}
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for child.TrackedCount() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if sites := child.markSites(); sites != "" {
+		t.Errorf("LeakDetection: Expected the mark to be released, got %q", sites)
+	}
+
+	cas.SetLeakDetection(0, false)
+	cas.Mark()
+	if sites := cas.markSites(); sites != "" {
+		t.Errorf("LeakDetection: Marks should not be recorded once disabled, got %q", sites)
+	}
+	cas.UnMark()
+	cas.Kill()
+}
@@ -0,0 +1,111 @@
+package cascade
+
+import (
+	"sync"
+)
+
+// Lock order
+//
+// A Cascade has several mutexes. Whenever more than one of them has to be held at the same time they MUST be
+// acquired in the order below (a lock may only be acquired while holding locks that come before it):
+//
+//	muChildren
+//	muActions
+//	muTracked
+//	muDead
+//	muCtx
+//	muErr
+//	muName, muMeta, muConfig, muMarks
+//
+// The last four are leaves: nothing else may be acquired while holding them, and they are never held
+// together. Locks of different Cascades are never held at the same time.
+//
+// Building with the `cascadedebug` build tag enables a checker that panics as soon as a goroutine acquires a
+// lock out of order, before it gets a chance to deadlock.
+
+// lockRank identifies the position of a mutex in the lock order.
+type lockRank interface {
+	rank() int
+	name() string
+}
+
+type (
+	rankChildren struct{}
+	rankActions  struct{}
+	rankTracked  struct{}
+	rankDead     struct{}
+	rankCtx      struct{}
+	rankErr      struct{}
+	rankName     struct{}
+	rankMeta     struct{}
+	rankConfig   struct{}
+	rankMarks    struct{}
+)
+
+func (rankChildren) rank() int { return 1 }
+func (rankActions) rank() int  { return 2 }
+func (rankTracked) rank() int  { return 3 }
+func (rankDead) rank() int     { return 4 }
+func (rankCtx) rank() int      { return 5 }
+func (rankErr) rank() int      { return 6 }
+func (rankName) rank() int     { return 7 }
+func (rankMeta) rank() int     { return 7 }
+func (rankConfig) rank() int   { return 7 }
+func (rankMarks) rank() int    { return 7 }
+
+func (rankChildren) name() string { return "muChildren" }
+func (rankActions) name() string  { return "muActions" }
+func (rankTracked) name() string  { return "muTracked" }
+func (rankDead) name() string     { return "muDead" }
+func (rankCtx) name() string      { return "muCtx" }
+func (rankErr) name() string      { return "muErr" }
+func (rankName) name() string     { return "muName" }
+func (rankMeta) name() string     { return "muMeta" }
+func (rankConfig) name() string   { return "muConfig" }
+func (rankMarks) name() string    { return "muMarks" }
+
+// rankedMutex is a `sync.Mutex` with a position in the lock order.
+type rankedMutex[R lockRank] struct {
+	mu sync.Mutex
+}
+
+func (m *rankedMutex[R]) Lock() {
+	var r R
+	acquireRank(r.rank(), r.name())
+	m.mu.Lock()
+}
+
+func (m *rankedMutex[R]) Unlock() {
+	var r R
+	m.mu.Unlock()
+	releaseRank(r.rank())
+}
+
+// rankedRWMutex is a `sync.RWMutex` with a position in the lock order.
+type rankedRWMutex[R lockRank] struct {
+	mu sync.RWMutex
+}
+
+func (m *rankedRWMutex[R]) Lock() {
+	var r R
+	acquireRank(r.rank(), r.name())
+	m.mu.Lock()
+}
+
+func (m *rankedRWMutex[R]) Unlock() {
+	var r R
+	m.mu.Unlock()
+	releaseRank(r.rank())
+}
+
+func (m *rankedRWMutex[R]) RLock() {
+	var r R
+	acquireRank(r.rank(), r.name())
+	m.mu.RLock()
+}
+
+func (m *rankedRWMutex[R]) RUnlock() {
+	var r R
+	m.mu.RUnlock()
+	releaseRank(r.rank())
+}
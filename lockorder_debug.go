@@ -0,0 +1,63 @@
+//go:build cascadedebug
+
+package cascade
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+type heldRank struct {
+	rank int
+	name string
+}
+
+var (
+	heldRanks   = make(map[uint64][]heldRank) // Locks held by each goroutine, in acquisition order
+	muHeldRanks sync.Mutex
+)
+
+// Panics if the calling goroutine already holds a lock at or after the provided rank, then records the lock
+func acquireRank(rank int, name string) {
+	id := goroutineID()
+	muHeldRanks.Lock()
+	defer muHeldRanks.Unlock()
+	for _, held := range heldRanks[id] {
+		if held.rank >= rank {
+			panic(fmt.Sprintf("cascade: lock order violation: acquiring %s while holding %s", name, held.name))
+		}
+	}
+	heldRanks[id] = append(heldRanks[id], heldRank{rank, name})
+}
+
+// Forgets the most recently acquired lock of the provided rank held by the calling goroutine
+func releaseRank(rank int) {
+	id := goroutineID()
+	muHeldRanks.Lock()
+	defer muHeldRanks.Unlock()
+	held := heldRanks[id]
+	for i := len(held) - 1; i >= 0; i-- {
+		if held[i].rank == rank {
+			held = append(held[:i], held[i+1:]...)
+			break
+		}
+	}
+	if len(held) == 0 {
+		delete(heldRanks, id)
+	} else {
+		heldRanks[id] = held
+	}
+}
+
+// Parses the ID of the calling goroutine out of its stack trace
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	buf = buf[:bytes.IndexByte(buf, ' ')]
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,25 @@
+//go:build cascadedebug
+
+package cascade
+
+import (
+	"testing"
+)
+
+func TestLockOrder(t *testing.T) {
+	cas := RootCascade()
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("LockOrder: Acquiring locks out of order did not panic!")
+		}
+		cas.muErr.Unlock()
+	}()
+
+	cas.muTracked.Lock()
+	cas.muDead.RLock() // In order
+	cas.muDead.RUnlock()
+	cas.muTracked.Unlock()
+
+	cas.muErr.Lock()
+	cas.muDead.Lock() // Out of order
+}
@@ -0,0 +1,7 @@
+//go:build !cascadedebug
+
+package cascade
+
+func acquireRank(rank int, name string) {}
+
+func releaseRank(rank int) {}
@@ -0,0 +1,139 @@
+package cascade
+
+import (
+	"time"
+)
+
+// Logger receives diagnostic messages from a Cascade.
+//
+// `*testing.T` satisfies this interface which makes it easy to surface messages in tests.
+type Logger interface {
+	Logf(format string, args ...interface{})
+}
+
+// SetLogger sets the Logger that the Cascade reports diagnostic messages to. Passing `nil` disables logging.
+//
+// Children created after this call inherit the Logger.
+func (c *Cascade) SetLogger(logger Logger) {
+	c.muConfig.Lock()
+	c.logger = logger
+	c.muConfig.Unlock()
+}
+
+// SetStrict enables or disables strict mode. In strict mode the Cascade reports likely misuse to its Logger,
+// such as being cancelled while it still has `DoOnKill` actions registered (which will never run).
+//
+// Strict mode is meant as a debugging aid and has no effect without a Logger (see `SetLogger`).
+// Children created after this call inherit the setting.
+func (c *Cascade) SetStrict(strict bool) {
+	c.muConfig.Lock()
+	c.strict = strict
+	c.muConfig.Unlock()
+}
+
+// SetVerbose enables or disables lifecycle logging. When enabled the Cascade reports to its Logger when it
+// starts being killed or cancelled, when each of its children has been stopped, when it becomes dead, when
+// its actions run, when it is done and when a tracked function or critical action panics.
+//
+// Like strict mode this has no effect without a Logger (see `SetLogger`), and messages are never logged while
+// a lock of the Cascade is held. Children created after this call inherit the setting.
+func (c *Cascade) SetVerbose(verbose bool) {
+	c.muConfig.Lock()
+	c.verbose = verbose
+	c.muConfig.Unlock()
+}
+
+// SetSlowChildThreshold makes the Cascade report children that are still tearing down after the provided
+// duration when the Cascade is killed or cancelled. Each slow child is reported once to the Logger
+// (see `SetLogger`). A duration of 0 disables the reports.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetSlowChildThreshold(d time.Duration) {
+	c.muConfig.Lock()
+	c.slowChild = d
+	c.muConfig.Unlock()
+}
+
+// SetWatchdog makes the Cascade report to its Logger (see `SetLogger`) when it has been killed or cancelled
+// but its tracked goroutines (see `Mark`) have not all exited after the provided duration. The report names
+// the Cascade and the number of goroutines that are still tracked, so a goroutine that never calls `UnMark`
+// can be traced to its subtree. The kill keeps waiting, the watchdog only surfaces the hang. Each teardown is
+// reported once. A duration of 0 disables the watchdog.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetWatchdog(d time.Duration) {
+	c.muConfig.Lock()
+	c.watchdog = d
+	c.muConfig.Unlock()
+}
+
+// SetLeakDetection is a development aid for finding goroutines that call `Mark` without a matching `UnMark`.
+// Once the Cascade has been killed or cancelled, if its tracked goroutines have not all exited after the
+// provided duration, it reports them to its Logger (see `SetLogger`) along with the call site of every `Mark`
+// that is still outstanding. If panics is `true`, the report is also raised as a panic so that a leaked mark
+// can't go unnoticed. The panic happens on a goroutine of its own and crashes the program, it is not meant for
+// production use.
+//
+// While leak detection is enabled every Mark records where it was called from, which makes it noticeably
+// slower. Marks are matched with UnMark by the function that calls them (closures count as part of the
+// function that encloses them), so only marks made while it is enabled are listed. The call site is the
+// first caller outside of this package, for `Go` and its variants that is where the function was launched.
+//
+// Leak detection is independent of the watchdog (see `SetWatchdog`). A duration of 0 disables it. Children
+// created after this call inherit the setting.
+func (c *Cascade) SetLeakDetection(d time.Duration, panics bool) {
+	c.muConfig.Lock()
+	c.leakAfter = d
+	c.leakPanic = panics
+	c.muConfig.Unlock()
+	c.leakTrack.Store(d > 0)
+	if d == 0 {
+		c.muMarks.Lock()
+		c.marks = nil
+		c.muMarks.Unlock()
+	}
+}
+
+func (c *Cascade) logf(format string, args ...interface{}) {
+	c.muConfig.RLock()
+	logger := c.logger
+	c.muConfig.RUnlock()
+	if logger != nil {
+		logger.Logf(format, args...)
+	}
+}
+
+// Reports a lifecycle event prefixed with the path of the Cascade if verbose logging is enabled. The path is
+// only built when the message is actually logged.
+func (c *Cascade) logEvent(format string, args ...interface{}) {
+	c.muConfig.RLock()
+	logger, verbose := c.logger, c.verbose
+	c.muConfig.RUnlock()
+	if logger != nil && verbose {
+		logger.Logf("cascade: %s "+format, append([]interface{}{c.Path()}, args...)...)
+	}
+}
+
+func (c *Cascade) isStrict() bool {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.strict
+}
+
+func (c *Cascade) slowChildThreshold() time.Duration {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.slowChild
+}
+
+func (c *Cascade) watchdogDuration() time.Duration {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.watchdog
+}
+
+func (c *Cascade) leakDetection() (time.Duration, bool) {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.leakAfter, c.leakPanic
+}
@@ -0,0 +1,248 @@
+package cascade
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testLogger collects every message it receives.
+type testLogger struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *testLogger) Logf(format string, args ...interface{}) {
+	l.mu.Lock()
+	l.msgs = append(l.msgs, fmt.Sprintf(format, args...))
+	l.mu.Unlock()
+}
+
+func (l *testLogger) messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.msgs...)
+}
+
+func TestCascade_SetStrict(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade()
+	cas.SetLogger(logger)
+	cas.SetStrict(true)
+	child := cas.ChildCascade()
+	child.SetName("child")
+
+	child.DoOnKill(func() {})
+	child.DoOnKill(func() {})
+
+	cas.Cancel()
+
+	if skipped := child.SkippedActions(); skipped != 2 {
+		t.Errorf("SetStrict: Expected 2 skipped actions, got %v", skipped)
+	}
+	if skipped := cas.SkippedActions(); skipped != 0 {
+		t.Errorf("SetStrict: Expected 0 skipped actions, got %v", skipped)
+	}
+
+	msgs := logger.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("SetStrict: Expected 1 message, got %v: %v", len(msgs), msgs)
+	}
+	if !strings.Contains(msgs[0], "-/child") || !strings.Contains(msgs[0], "2 kill action(s)") {
+		t.Errorf("SetStrict: Message did not identify the Cascade and skipped actions: %q", msgs[0])
+	}
+}
+
+func TestCascade_SetStrictDisabled(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade()
+	cas.SetLogger(logger)
+	cas.DoOnKill(func() {})
+
+	cas.Cancel()
+
+	if skipped := cas.SkippedActions(); skipped != 1 {
+		t.Errorf("SetStrictDisabled: Expected 1 skipped action, got %v", skipped)
+	}
+	if msgs := logger.messages(); len(msgs) != 0 {
+		t.Errorf("SetStrictDisabled: Expected no messages, got %v", msgs)
+	}
+}
+
+func TestCascade_SetStrictKill(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade()
+	cas.SetLogger(logger)
+	cas.SetStrict(true)
+	cas.DoOnKill(func() {})
+
+	cas.Kill()
+
+	if skipped := cas.SkippedActions(); skipped != 0 {
+		t.Errorf("SetStrictKill: Expected 0 skipped actions, got %v", skipped)
+	}
+	if msgs := logger.messages(); len(msgs) != 0 {
+		t.Errorf("SetStrictKill: Expected no messages, got %v", msgs)
+	}
+}
+
+func TestCascade_SetSlowChildThreshold(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade()
+	cas.SetName("root")
+	cas.SetLogger(logger)
+	cas.SetSlowChildThreshold(time.Second / 10)
+
+	slow := cas.ChildCascade()
+	slow.SetName("slow")
+	fast := cas.ChildCascade()
+	fast.SetName("fast")
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		slow.Mark()
+		defer slow.UnMark()
+		wg.Done()
+		slow.Hold()
+		<-time.After(time.Second / 2) // Take a while to exit
+	}()
+	wg.Wait()
+
+	cas.Kill()
+
+	msgs := logger.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("SetSlowChildThreshold: Expected 1 message, got %v: %v", len(msgs), msgs)
+	}
+	if !strings.Contains(msgs[0], "root/slow") {
+		t.Errorf("SetSlowChildThreshold: Message did not identify the slow child: %q", msgs[0])
+	}
+
+	// Timers for children that finished in time must not fire later.
+	<-time.After(time.Second / 5)
+	if msgs := logger.messages(); len(msgs) != 1 {
+		t.Errorf("SetSlowChildThreshold: Expected no further messages, got %v", msgs)
+	}
+}
+
+func TestCascade_SetVerbose(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade(WithName("root"), WithLogger(logger), WithVerbose(), WithRecover())
+	child := cas.ChildCascade(WithName("child"))
+	cas.DoOnKill(func() {})
+	child.DoCritical(func() { panic("boom") })
+
+	cas.Kill()
+
+	expected := []string{
+		"cascade: root kill initiated",
+		"cascade: root/child kill initiated",
+		"cascade: root/child is dead",
+		"cascade: root/child recovered panic in critical action: boom",
+		"cascade: root/child running kill actions",
+		"cascade: root/child is done",
+		"cascade: root child root/child stopped (kill)",
+		"cascade: root is dead",
+		"cascade: root running kill actions",
+		"cascade: root is done",
+	}
+	msgs := logger.messages()
+	if len(msgs) != len(expected) {
+		t.Fatalf("SetVerbose: Expected %v messages, got %v: %v", len(expected), len(msgs), msgs)
+	}
+	for i := range expected {
+		if msgs[i] != expected[i] {
+			t.Errorf("SetVerbose: Expected message %v to be %q, got %q", i, expected[i], msgs[i])
+		}
+	}
+}
+
+func TestCascade_SetVerboseDisabled(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade(WithLogger(logger))
+	cas.ChildCascade()
+	cas.Cancel()
+
+	if msgs := logger.messages(); len(msgs) != 0 {
+		t.Errorf("SetVerboseDisabled: Expected no messages, got %v", msgs)
+	}
+}
+
+func TestCascade_SetWatchdog(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade(WithName("root"), WithLogger(logger), WithWatchdog(time.Second/20))
+	stuck := cas.ChildCascade(WithName("stuck"))
+	stuck.Mark()
+	stuck.Mark() // Never unmarked until the end of the test
+
+	killed := make(chan struct{})
+	go func() {
+		cas.Kill()
+		close(killed)
+	}()
+	<-time.After(time.Second / 5)
+
+	select {
+	case <-killed:
+		t.Fatal("SetWatchdog: Kill should keep waiting for the stuck goroutines!")
+	default:
+	}
+	msgs := logger.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("SetWatchdog: Expected 1 message, got %v: %v", len(msgs), msgs)
+	}
+	if !strings.Contains(msgs[0], "root/stuck") || !strings.Contains(msgs[0], "2 tracked goroutine(s)") {
+		t.Errorf("SetWatchdog: Message did not identify the stuck Cascade and its goroutines: %q", msgs[0])
+	}
+
+	stuck.UnMark()
+	stuck.UnMark()
+	<-killed
+	if msgs := logger.messages(); len(msgs) != 1 {
+		t.Errorf("SetWatchdog: Expected no further messages, got %v", msgs)
+	}
+}
+
+func TestCascade_SetLeakDetection(t *testing.T) {
+	panicked := make(chan string, 1)
+	defer func(previous func(string)) { leakPanic = previous }(leakPanic)
+	leakPanic = func(msg string) { panicked <- msg }
+
+	logger := &testLogger{}
+	cas := RootCascade(WithName("leaky"), WithLogger(logger), WithWatchdog(time.Hour),
+		WithLeakDetection(time.Second/20, true))
+	if cas.watchdogDuration() != time.Hour {
+		t.Error("SetLeakDetection: Should not change the watchdog!")
+	}
+	cas.Wrap(func(c *Cascade) {}) // Balanced marks are not listed
+	done := make(chan struct{})
+	go func() {
+		cas.Mark() // Forgets to UnMark
+		close(done)
+	}()
+	<-done
+	go cas.Kill()
+
+	select {
+	case msg := <-panicked:
+		if !strings.Contains(msg, "leaky") || !strings.Contains(msg, "1 tracked goroutine(s)") {
+			t.Errorf("SetLeakDetection: Panic did not identify the Cascade and its goroutines: %q", msg)
+		}
+		if strings.Count(msg, "logger_test.go:") != 1 {
+			t.Errorf("SetLeakDetection: Panic did not list the call site of the leaked mark: %q", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("SetLeakDetection: Leak was not detected!")
+	}
+	if msgs := logger.messages(); len(msgs) != 1 {
+		t.Errorf("SetLeakDetection: Expected the leak to be logged as well, got %v", msgs)
+	}
+
+	cas.UnMark()
+	if !cas.WaitDoneWithTimeout(time.Second) {
+		t.Error("SetLeakDetection: Kill should complete once the goroutine is unmarked!")
+	}
+}
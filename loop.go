@@ -0,0 +1,277 @@
+package cascade
+
+import (
+	"sync"
+	"time"
+)
+
+// LoopResult tells `WrapInLoopWithResult` and `GoInLoopWithResult` how to continue after the function returns.
+type LoopResult int
+
+const (
+	// LoopContinue calls the function again right away.
+	LoopContinue LoopResult = iota
+	// LoopRetry calls the function again after a backoff. The backoff starts at 10ms and doubles with every
+	// consecutive retry up to 1s, it is reset as soon as the function returns anything else.
+	LoopRetry
+	// LoopStop exits the loop.
+	LoopStop
+)
+
+const (
+	minRetryBackoff = 10 * time.Millisecond
+	maxRetryBackoff = time.Second
+)
+
+// WrapInLoopWithResult wraps a function inside a loop and runs it as a tracked function until the Cascade is
+// killed or cancelled or the provided function returns `LoopStop`. See `LoopResult` for how the loop continues.
+//
+// This is NOT a goroutine and will block until the provided function exits.
+//
+// The provided function MUST not block. The backoff after `LoopRetry` is interrupted when the Cascade starts
+// dying.
+func (c *Cascade) WrapInLoopWithResult(f func() LoopResult) {
+	c.wrapInLoopWithBackoff(f, minRetryBackoff, maxRetryBackoff)
+}
+
+// Runs the loop of WrapInLoopWithResult with the provided backoff schedule
+func (c *Cascade) wrapInLoopWithBackoff(f func() LoopResult, base, max time.Duration) {
+	if !c.Mark() {
+		return
+	}
+	defer c.UnMark()
+	defer c.handlePanic()
+	backoff := base
+	for {
+		select {
+		case <-c.Dying():
+			return
+		default:
+		}
+		switch f() {
+		case LoopStop:
+			return
+		case LoopRetry:
+			timer := time.NewTimer(backoff)
+			select {
+			case <-c.Dying():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if backoff *= 2; backoff > max {
+				backoff = max
+			}
+		default:
+			backoff = base
+		}
+	}
+}
+
+// GoInLoopWithResult wraps a function inside a loop and runs it as a tracked goroutine until the Cascade is
+// killed or cancelled or the provided function returns `LoopStop` (see `WrapInLoopWithResult`).
+//
+// The returned Cascade is a child of the current Cascade that is tracking the provided function.
+func (c *Cascade) GoInLoopWithResult(f func() LoopResult) *Cascade {
+	child := c.ChildCascade()
+	child.launch(func() { child.WrapInLoopWithResult(f) })
+	return child
+}
+
+// WrapInLoopWithError wraps a function inside a loop and runs it as a tracked function until the Cascade is
+// killed or cancelled or the provided function returns an error. A returned error breaks the loop and kills
+// the Cascade with it (just like `KillWithError`), it can be retrieved with `Error`.
+//
+// This is NOT a goroutine and will block until the provided function exits. The error is set before
+// WrapInLoopWithError returns, the kill itself happens in the background since it has to wait for this
+// function to exit.
+//
+// The provided function MUST not block, it will continue getting called until the Cascade is killed or cancelled
+// or the provided function returns an error.
+func (c *Cascade) WrapInLoopWithError(f func() error) {
+	if !c.Mark() {
+		return
+	}
+	defer c.UnMark()
+	defer c.handlePanic()
+	for {
+		select {
+		case <-c.Dying():
+			return
+		default:
+		}
+		if err := f(); err != nil {
+			_ = c.setOrJoinError(err) // Dropped like with KillWithError if another error is already set
+			go c.Kill()
+			return
+		}
+	}
+}
+
+// GoInLoopWithError wraps a function inside a loop and runs it as a tracked goroutine until the Cascade is
+// killed or cancelled or the provided function returns an error (see `WrapInLoopWithError`).
+//
+// The returned Cascade is a child of the current Cascade that is tracking the provided function, it is the
+// one that is killed with the error.
+func (c *Cascade) GoInLoopWithError(f func() error) *Cascade {
+	child := c.ChildCascade()
+	child.launch(func() { child.WrapInLoopWithError(f) })
+	return child
+}
+
+// GoInLoopWithBackoff wraps a function inside a loop and runs it as a tracked goroutine until the Cascade is
+// killed or cancelled. Every time the function returns an error the loop waits before calling it again, the
+// wait starts at base and doubles with every consecutive error up to max. It is reset to base as soon as the
+// function succeeds, successful calls are followed by the next call right away.
+//
+// For example a base of 100ms and a max of 1s waits 100ms, 200ms, 400ms, 800ms, 1s, 1s... between failures.
+//
+// The wait is interrupted as soon as the Cascade starts dying. A base of 0 or less is treated as 10ms, and a max
+// below base is treated as base.
+//
+// The returned Cascade is a child of the current Cascade that is tracking the provided function.
+func (c *Cascade) GoInLoopWithBackoff(f func() error, base, max time.Duration) *Cascade {
+	if base <= 0 {
+		base = minRetryBackoff
+	}
+	if max < base {
+		max = base
+	}
+	child := c.ChildCascade()
+	child.launch(func() {
+		child.wrapInLoopWithBackoff(func() LoopResult {
+			if f() != nil {
+				return LoopRetry
+			}
+			return LoopContinue
+		}, base, max)
+	})
+	return child
+}
+
+// GoLimited runs the provided function as tracked goroutines over and over again, with at most max
+// invocations running at the same time. As soon as an invocation returns, a new one is started in its place.
+// A max below 1 is treated as 1.
+//
+// Once the Cascade starts dying no new invocations are started, the ones that are already running are allowed
+// to finish and the returned Cascade only becomes dead after all of them have returned.
+//
+// The provided function SHOULD block while it has work to do (for example serving a single request) and
+// should exit once the Cascade is dying (see `Dying`).
+//
+// The returned Cascade is a child of the current Cascade that is tracking every invocation of the function.
+func (c *Cascade) GoLimited(max int, f func(*Cascade)) *Cascade {
+	if max < 1 {
+		max = 1
+	}
+	child := c.ChildCascade()
+	child.launch(func() { child.runLimited(max, f) })
+	return child
+}
+
+// Starts invocations of the provided function as long as there are free permits, see GoLimited
+func (c *Cascade) runLimited(max int, f func(*Cascade)) {
+	permits := make(chan struct{}, max)
+	for {
+		select {
+		case <-c.Dying():
+			return
+		case permits <- struct{}{}:
+		}
+		select {
+		case <-c.Dying(): // Both were ready, dying wins
+			<-permits
+			return
+		default:
+		}
+		if !c.Mark() {
+			return
+		}
+		go func() {
+			defer c.UnMark()
+			defer func() { <-permits }()
+			defer c.handlePanic()
+			f(c)
+		}()
+	}
+}
+
+// GoN runs n copies of the provided function as tracked goroutines, passing each its index from 0 to n-1. This
+// is meant for a fixed set of identical workers, such as consumers of partitioned work.
+//
+// The returned Cascade is a single child of the current Cascade that is tracking every worker, so killing it
+// stops all of them and it only becomes dead after every worker has returned.
+//
+// The provided function MUST implement an exit condition using the provided Cascade, see `Go`.
+func (c *Cascade) GoN(n int, f func(*Cascade, int)) *Cascade {
+	child := c.ChildCascade()
+	child.launch(func() {
+		wg := sync.WaitGroup{}
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				child.Wrap(func(cc *Cascade) { f(cc, i) })
+			}(i)
+		}
+		wg.Wait() // The launched function only finishes once every worker has returned
+	})
+	return child
+}
+
+// GoInLoopWithInterval runs the provided function as a tracked goroutine over and over again, waiting for the
+// provided interval after every call, until the Cascade is killed or cancelled.
+//
+// The wait is interrupted as soon as the Cascade starts dying, so a killed Cascade does not have to wait out
+// the rest of the interval.
+//
+// The returned Cascade is a child of the current Cascade that is tracking the provided function.
+func (c *Cascade) GoInLoopWithInterval(d time.Duration, f func()) *Cascade {
+	return c.GoInLoopWithIntervalWithBool(d, func() bool {
+		f()
+		return true
+	})
+}
+
+// GoInLoopWithIntervalWithBool runs the provided function as a tracked goroutine (just like
+// `GoInLoopWithInterval`) as long as the function returns `true`.
+//
+// The returned Cascade is a child of the current Cascade that is tracking the provided function.
+func (c *Cascade) GoInLoopWithIntervalWithBool(d time.Duration, f func() bool) *Cascade {
+	child := c.ChildCascade()
+	child.launch(func() { child.wrapInIntervalLoop(d, f) })
+	return child
+}
+
+// Calls the provided function, waiting for the interval between calls, see GoInLoopWithInterval
+func (c *Cascade) wrapInIntervalLoop(d time.Duration, f func() bool) {
+	if !c.Mark() {
+		return
+	}
+	defer c.UnMark()
+	defer c.handlePanic()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		select {
+		case <-c.Dying():
+			return
+		default:
+		}
+		if !f() {
+			return
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(d)
+		select {
+		case <-c.Dying():
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package cascade
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCascade_GoInLoopWithResult(t *testing.T) {
+	cas := RootCascade()
+	calls := atomic.Int32{}
+	start := time.Now()
+	child := cas.GoInLoopWithResult(func() LoopResult {
+		switch calls.Add(1) {
+		case 1:
+			return LoopContinue
+		case 2, 3:
+			return LoopRetry
+		default:
+			return LoopStop
+		}
+	})
+
+	for i := 0; i < 100 && child.TrackedCount() > 0; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	if child.TrackedCount() > 0 {
+		t.Fatal("GoInLoopWithResult: Loop did not stop!")
+	}
+	if calls.Load() != 4 {
+		t.Errorf("GoInLoopWithResult: Expected 4 calls, got %v", calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed < minRetryBackoff*3 {
+		t.Errorf("GoInLoopWithResult: Retries did not back off, took %v", elapsed)
+	}
+	if child.IsDead() {
+		t.Error("GoInLoopWithResult: Stopping should not kill the Cascade!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_GoInLoopWithResultRetryKill(t *testing.T) {
+	cas := RootCascade()
+	calls := atomic.Int32{}
+	cas.GoInLoopWithResult(func() LoopResult {
+		calls.Add(1)
+		return LoopRetry
+	})
+	for i := 0; i < 150 && calls.Load() < 7; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	// The backoff is now long enough that the kill has to interrupt it
+	go cas.Kill()
+	if !didExitBeforeTime(cas, maxRetryBackoff/2) {
+		t.Error("GoInLoopWithResultRetryKill: Backoff was not interrupted!")
+	}
+}
+
+func TestCascade_GoLimited(t *testing.T) {
+	cas := RootCascade()
+	var running, highest, calls atomic.Int32
+	child := cas.GoLimited(3, func(cc *Cascade) {
+		calls.Add(1)
+		now := running.Add(1)
+		for {
+			seen := highest.Load()
+			if now <= seen || highest.CompareAndSwap(seen, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+	})
+
+	time.Sleep(100 * time.Millisecond)
+	cas.Kill()
+	if child.Alive() {
+		t.Error("GoLimited: Child should be dead!")
+	}
+	if running.Load() != 0 {
+		t.Errorf("GoLimited: Expected no running invocations after kill, got %d", running.Load())
+	}
+	if highest.Load() > 3 {
+		t.Errorf("GoLimited: Concurrency exceeded max, got %d", highest.Load())
+	}
+	if highest.Load() < 2 || calls.Load() < 6 {
+		t.Errorf("GoLimited: Expected invocations to run concurrently, got %d at once and %d total", highest.Load(), calls.Load())
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_GoInLoopWithInterval(t *testing.T) {
+	cas := RootCascade()
+	calls := atomic.Int32{}
+	cas.GoInLoopWithInterval(20*time.Millisecond, func() { calls.Add(1) })
+
+	time.Sleep(110 * time.Millisecond)
+	if n := calls.Load(); n < 4 || n > 7 {
+		t.Errorf("GoInLoopWithInterval: Expected around 6 calls, got %d", n)
+	}
+
+	slow := RootCascade()
+	slow.GoInLoopWithInterval(time.Hour, func() {})
+	time.Sleep(10 * time.Millisecond)
+	go slow.Kill()
+	if !didExitBeforeTime(slow, 100*time.Millisecond) {
+		t.Error("GoInLoopWithInterval: Kill should interrupt the interval!")
+	}
+	cas.Kill()
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_GoInLoopWithIntervalWithBool(t *testing.T) {
+	cas := RootCascade()
+	calls := atomic.Int32{}
+	cas.GoInLoopWithIntervalWithBool(time.Millisecond, func() bool {
+		return calls.Add(1) < 3
+	})
+	time.Sleep(50 * time.Millisecond)
+	if calls.Load() != 3 {
+		t.Errorf("GoInLoopWithIntervalWithBool: Expected 3 calls, got %d", calls.Load())
+	}
+	cas.Kill()
+}
+
+func TestCascade_GoInLoopWithError(t *testing.T) {
+	cas := RootCascade()
+	calls := atomic.Int32{}
+	fatal := errors.New("fatal")
+	child := cas.GoInLoopWithError(func() error {
+		if calls.Add(1) == 5 {
+			return fatal
+		}
+		return nil
+	})
+
+	if !didExitBeforeTime(child, time.Second) {
+		t.Fatal("GoInLoopWithError: Returned error should kill the Cascade!")
+	}
+	if calls.Load() != 5 {
+		t.Errorf("GoInLoopWithError: Expected 5 calls, got %d", calls.Load())
+	}
+	if child.Error() != fatal {
+		t.Errorf("GoInLoopWithError: Expected %v, got %v", fatal, child.Error())
+	}
+	if !cas.Alive() {
+		t.Error("GoInLoopWithError: Parent should still be alive!")
+	}
+	cas.Kill()
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_WrapInLoopWithError(t *testing.T) {
+	cas := RootCascade()
+	fatal := errors.New("fatal")
+	cas.WrapInLoopWithError(func() error { return fatal })
+	if cas.Error() != fatal {
+		t.Errorf("WrapInLoopWithError: Expected %v, got %v", fatal, cas.Error())
+	}
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Error("WrapInLoopWithError: Returned error should kill the Cascade!")
+	}
+
+	cas = RootCascade()
+	go cas.Kill()
+	cas.WrapInLoopWithError(func() error { return nil })
+	if cas.Error() != ErrKilled {
+		t.Errorf("WrapInLoopWithError: Expected %v, got %v", ErrKilled, cas.Error())
+	}
+
+	cas = RootCascade(WithErrorJoining())
+	first := errors.New("first")
+	cas.AddError(first)
+	cas.WrapInLoopWithError(func() error { return fatal })
+	if !errors.Is(cas.Error(), first) || !errors.Is(cas.Error(), fatal) {
+		t.Errorf("WrapInLoopWithError: Expected the error to be joined, got %v", cas.Error())
+	}
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Error("WrapInLoopWithError: Returned error should kill the Cascade!")
+	}
+}
+
+func TestCascade_GoInLoopWithBackoff(t *testing.T) {
+	cas := RootCascade()
+	failure := errors.New("failure")
+	var calls atomic.Int32
+	times := make(chan time.Time, 16)
+	cas.GoInLoopWithBackoff(func() error {
+		n := calls.Add(1)
+		if n <= 8 {
+			times <- time.Now()
+		}
+		if n <= 3 || (n >= 5 && n <= 7) {
+			return failure // Fail 3 times, succeed once, then fail again
+		}
+		return nil
+	}, 10*time.Millisecond, 25*time.Millisecond)
+
+	stamps := make([]time.Time, 8)
+	for i := range stamps {
+		stamps[i] = <-times
+	}
+	gaps := make([]time.Duration, 7)
+	for i := range gaps {
+		gaps[i] = stamps[i+1].Sub(stamps[i])
+	}
+	expected := []time.Duration{10, 20, 25, 0, 10, 20, 25}
+	for i, want := range expected {
+		want *= time.Millisecond
+		if gaps[i] < want || gaps[i] > want+40*time.Millisecond {
+			t.Errorf("GoInLoopWithBackoff: Expected gap %d to be about %s, got %s", i, want, gaps[i])
+		}
+	}
+	cas.Kill()
+
+	slow := RootCascade()
+	slow.GoInLoopWithBackoff(func() error { return failure }, time.Hour, time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	go slow.Kill()
+	if !didExitBeforeTime(slow, 100*time.Millisecond) {
+		t.Error("GoInLoopWithBackoff: Kill should interrupt the backoff!")
+	}
+}
+
+func TestCascade_GoN(t *testing.T) {
+	cas := RootCascade()
+	mu := sync.Mutex{}
+	seen := make(map[int]int)
+	started := sync.WaitGroup{}
+	started.Add(8)
+	exited := atomic.Int64{}
+	child := cas.GoN(8, func(c *Cascade, i int) {
+		mu.Lock()
+		seen[i]++
+		mu.Unlock()
+		started.Done()
+		<-c.Dying()
+		<-time.After(time.Duration(i) * time.Millisecond)
+		exited.Add(1)
+	})
+	started.Wait()
+
+	if len(cas.Children()) != 1 {
+		t.Error("GoN: Expected a single child for all workers!")
+	}
+	mu.Lock()
+	for i := 0; i < 8; i++ {
+		if seen[i] != 1 {
+			t.Errorf("GoN: Expected index %v to be seen once, it was seen %v times", i, seen[i])
+		}
+	}
+	if len(seen) != 8 {
+		t.Errorf("GoN: Expected 8 indices, got %v", seen)
+	}
+	mu.Unlock()
+
+	child.Kill()
+	if count := exited.Load(); count != 8 {
+		t.Errorf("GoN: Kill should wait for every worker, %v exited", count)
+	}
+	if cas.IsDead() {
+		t.Error("GoN: Killing the child should not kill the parent!")
+	}
+	cas.Kill()
+}
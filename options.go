@@ -0,0 +1,77 @@
+package cascade
+
+import (
+	"time"
+)
+
+// Option configures a Cascade as it is created, see `RootCascade` and `ChildCascade`. Every option has a
+// matching setter that can be used on an existing Cascade.
+type Option func(*Cascade)
+
+// WithName names the Cascade, see `SetName`.
+func WithName(name string) Option {
+	return func(c *Cascade) { c.SetName(name) }
+}
+
+// WithRecover recovers panics in tracked functions, see `SetRecover`.
+func WithRecover() Option {
+	return func(c *Cascade) { c.SetRecover(true) }
+}
+
+// WithPanicPolicy sets how panics in tracked functions are handled, see `SetPanicPolicy`.
+func WithPanicPolicy(policy PanicPolicy) Option {
+	return func(c *Cascade) { c.SetPanicPolicy(policy) }
+}
+
+// WithKillTimeout limits how long `Close` waits for the Cascade to become dead, see `SetKillTimeout`.
+func WithKillTimeout(d time.Duration) Option {
+	return func(c *Cascade) { c.SetKillTimeout(d) }
+}
+
+// WithMaxTrackedContexts limits the number of Contexts tracked by the Cascade, see `SetMaxTrackedContexts`.
+func WithMaxTrackedContexts(n int) Option {
+	return func(c *Cascade) { c.SetMaxTrackedContexts(n) }
+}
+
+// WithWatchdog reports tracked goroutines that do not exit after a kill, see `SetWatchdog`.
+func WithWatchdog(d time.Duration) Option {
+	return func(c *Cascade) { c.SetWatchdog(d) }
+}
+
+// WithParallelActions runs the actions of the Cascade concurrently, see `SetParallelActions`.
+func WithParallelActions() Option {
+	return func(c *Cascade) { c.SetParallelActions(true) }
+}
+
+// WithActionTimeout limits how long each action of the Cascade may run, see `SetActionTimeout`.
+func WithActionTimeout(d time.Duration) Option {
+	return func(c *Cascade) { c.SetActionTimeout(d) }
+}
+
+// WithErrorJoining makes KillWithError and CancelWithError join errors, see `SetErrorJoining`.
+func WithErrorJoining() Option {
+	return func(c *Cascade) { c.SetErrorJoining(true) }
+}
+
+// WithLeakDetection reports (and optionally panics on) goroutines that are never unmarked, see
+// `SetLeakDetection`.
+func WithLeakDetection(d time.Duration, panics bool) Option {
+	return func(c *Cascade) { c.SetLeakDetection(d, panics) }
+}
+
+// WithLogger sets the Logger of the Cascade, see `SetLogger`.
+func WithLogger(logger Logger) Option {
+	return func(c *Cascade) { c.SetLogger(logger) }
+}
+
+// WithVerbose enables lifecycle logging to the Logger of the Cascade, see `SetVerbose`.
+func WithVerbose() Option {
+	return func(c *Cascade) { c.SetVerbose(true) }
+}
+
+// Applies the options to the Cascade in order
+func (c *Cascade) apply(opts []Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
@@ -0,0 +1,77 @@
+package cascade
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRootCascade_Options(t *testing.T) {
+	cas := RootCascade(WithName("root"), WithRecover(), WithKillTimeout(20*time.Millisecond))
+	if cas.Name() != "root" || cas.getPanicPolicy() != PanicKillOwner || cas.getKillTimeout() != 20*time.Millisecond {
+		t.Error("Options: Options were not applied!")
+	}
+
+	// Children inherit the configuration but not the name, their own options come on top
+	child := cas.ChildCascade(WithName("child"))
+	other := cas.ChildCascade()
+	if child.Name() != "child" || other.Name() != "" {
+		t.Error("Options: Names should not be inherited!")
+	}
+	if other.getPanicPolicy() != PanicKillOwner || other.getKillTimeout() != 20*time.Millisecond {
+		t.Error("Options: Configuration should be inherited!")
+	}
+
+	// The recover option turns a panic into an error
+	child.Go(func(cc *Cascade) { panic("boom") })
+	if !didExitBeforeTime(child, time.Second) {
+		t.Fatal("Options: Panic should kill the owner!")
+	}
+	var panicErr *PanicError
+	if !errors.As(child.Error(), &panicErr) {
+		t.Errorf("Options: Expected a PanicError, got %v", child.Error())
+	}
+
+	// The kill timeout bounds Close
+	release := make(chan struct{})
+	cas.Mark()
+	go func() {
+		defer cas.UnMark()
+		<-release
+	}()
+	var timeout *KillTimeoutError
+	if err := cas.Close(); !errors.As(err, &timeout) {
+		t.Errorf("Options: Expected Close to time out, got %v", err)
+	}
+	close(release)
+	cas.WaitDone()
+
+	if RootCascade().Close() != nil {
+		t.Error("Options: A Cascade without options should close normally!")
+	}
+}
+
+func TestCascade_InheritedOptions(t *testing.T) {
+	logger := &testLogger{}
+	cas := RootCascade(WithName("root"), WithRecover(), WithLogger(logger), WithKillTimeout(time.Second))
+	grandchild := cas.ChildCascade().ChildCascade()
+	if grandchild.getPanicPolicy() != PanicKillOwner || grandchild.getKillTimeout() != time.Second {
+		t.Error("ChildCascade: Recover and kill timeout should reach every level!")
+	}
+	grandchild.muConfig.RLock()
+	inherited := grandchild.logger == Logger(logger)
+	grandchild.muConfig.RUnlock()
+	if !inherited {
+		t.Error("ChildCascade: Logger should be inherited!")
+	}
+
+	cas.setErrorIfUnset(errors.New("root only"))
+	deviant := cas.ChildCascade(WithPanicPolicy(PanicCrash))
+	if deviant.getPanicPolicy() != PanicCrash || deviant.getKillTimeout() != time.Second {
+		t.Error("ChildCascade: Options should override only what they set!")
+	}
+	if deviant.Error() != nil || deviant.Name() != "" {
+		t.Error("ChildCascade: Per-node state should not be inherited!")
+	}
+	cas.Kill()
+}
@@ -0,0 +1,90 @@
+package cascade
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicPolicy decides what happens when a function tracked with `Wrap`, `WrapInLoop`, `WrapInLoopWithBool` or
+// one of the matching `Go` variants panics.
+type PanicPolicy int
+
+const (
+	// PanicCrash lets the panic propagate, crashing the program. This is the default.
+	PanicCrash PanicPolicy = iota
+	// PanicRecoverLocal recovers the panic and sets it as the error of the Cascade tracking the function
+	// (see `Error`). The function exits but the Cascade is not killed.
+	PanicRecoverLocal
+	// PanicKillTree recovers the panic, sets it as the error of the Cascade tracking the function and kills
+	// the whole tree (see `KillAllWithError`).
+	PanicKillTree
+	// PanicKillOwner recovers the panic, sets it as the error of the Cascade tracking the function and kills
+	// the Cascade that owns the function with the panic as its error (see `KillWithError`). For `Wrap` and its
+	// variants this is the Cascade itself, for `Go` and its variants it is the Cascade that `Go` was called on,
+	// so the panic shuts down the siblings of the panicking function as well.
+	PanicKillOwner
+)
+
+// PanicError is the error that is set on a Cascade when a panic is recovered according to its `PanicPolicy`.
+type PanicError struct {
+	Value interface{} // The value that was passed to panic
+	Stack []byte      // The stack trace of the goroutine that panicked
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("cascade: recovered panic: %v", e.Value)
+}
+
+// SetPanicPolicy sets how the Cascade handles panics in tracked functions (see `PanicPolicy`).
+//
+// Children created after this call inherit the policy, so it is usually set on the `RootCascade` right
+// after it is created.
+func (c *Cascade) SetPanicPolicy(policy PanicPolicy) {
+	c.muConfig.Lock()
+	c.panicPolicy = policy
+	c.muConfig.Unlock()
+}
+
+// SetRecover enables or disables recovering panics in tracked functions. Enabling it is the same as
+// `SetPanicPolicy(PanicKillOwner)`, disabling it is the same as `SetPanicPolicy(PanicCrash)`.
+//
+// Children created after this call inherit the setting.
+func (c *Cascade) SetRecover(enabled bool) {
+	if enabled {
+		c.SetPanicPolicy(PanicKillOwner)
+	} else {
+		c.SetPanicPolicy(PanicCrash)
+	}
+}
+
+// Applies the panic policy. MUST be deferred directly so that it can recover.
+func (c *Cascade) handlePanic() {
+	policy := c.getPanicPolicy()
+	if policy == PanicCrash {
+		return
+	}
+	r := recover()
+	if r == nil {
+		return
+	}
+	err := &PanicError{Value: r, Stack: debug.Stack()}
+	c.setErrorIfUnset(err)
+	c.logEvent("recovered panic in tracked function: %v", r)
+	// The panicking function is still tracked so the kills can't be waited on here
+	switch policy {
+	case PanicKillTree:
+		go c.KillAllWithError(err)
+	case PanicKillOwner:
+		owner := c
+		if parent := c.Parent(); c.launched.Load() && parent != nil {
+			owner = parent
+		}
+		go owner.KillWithError(err)
+	}
+}
+
+func (c *Cascade) getPanicPolicy() PanicPolicy {
+	c.muConfig.RLock()
+	defer c.muConfig.RUnlock()
+	return c.panicPolicy
+}
@@ -0,0 +1,106 @@
+package cascade
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCascade_SetPanicPolicyRecoverLocal(t *testing.T) {
+	cas := RootCascade()
+	cas.SetPanicPolicy(PanicRecoverLocal)
+
+	child := cas.Go(func(c *Cascade) {
+		panic("boom")
+	})
+	for i := 0; i < 100 && child.TrackedCount() > 0; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(child.Error(), &panicErr) || panicErr.Value != "boom" {
+		t.Fatalf("SetPanicPolicyRecoverLocal: Expected the panic as error, got %v", child.Error())
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Error("SetPanicPolicyRecoverLocal: Stack was not recorded!")
+	}
+	if child.IsDead() || cas.IsDead() {
+		t.Error("SetPanicPolicyRecoverLocal: Cascade was killed!")
+	}
+	cas.Kill()
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_SetPanicPolicyKillTree(t *testing.T) {
+	cas := RootCascade()
+	cas.SetPanicPolicy(PanicKillTree)
+	other := cas.Go(func(c *Cascade) {
+		c.Hold()
+	})
+
+	child := cas.ChildCascade().GoInLoop(func() {
+		panic("boom")
+	})
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Fatal("SetPanicPolicyKillTree: Tree was not killed!")
+	}
+	cas.WaitDone()
+
+	var panicErr *PanicError
+	if !errors.As(cas.Error(), &panicErr) || !errors.As(child.Error(), &panicErr) {
+		t.Errorf("SetPanicPolicyKillTree: Expected the panic as error, got %v and %v", cas.Error(), child.Error())
+	}
+	if !other.IsDead() {
+		t.Error("SetPanicPolicyKillTree: Sibling was not killed!")
+	}
+}
+
+func TestCascade_SetPanicPolicyDefault(t *testing.T) {
+	cas := RootCascade()
+	if cas.getPanicPolicy() != PanicCrash {
+		t.Error("SetPanicPolicyDefault: Panics should crash by default!")
+	}
+	cas.SetPanicPolicy(PanicRecoverLocal)
+	if cas.ChildCascade().getPanicPolicy() != PanicRecoverLocal {
+		t.Error("SetPanicPolicyDefault: Policy was not inherited!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_SetRecover(t *testing.T) {
+	cas := RootCascade()
+	parent := cas.ChildCascade()
+	parent.SetRecover(true)
+	sibling := parent.Go(func(c *Cascade) {
+		c.Hold()
+	})
+
+	child := parent.Go(func(c *Cascade) {
+		panic("boom")
+	})
+	if !didExitBeforeTime(parent, time.Second) {
+		t.Fatal("SetRecover: Parent was not killed!")
+	}
+	parent.WaitDone()
+
+	var panicErr *PanicError
+	if !errors.As(parent.Error(), &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("SetRecover: Expected the panic as the parent's error, got %v", parent.Error())
+	}
+	if child.Error() != parent.Error() {
+		t.Error("SetRecover: Panic was not recorded on the child!")
+	}
+	if !sibling.IsDead() {
+		t.Error("SetRecover: Sibling was not killed!")
+	}
+	if cas.IsDead() {
+		t.Error("SetRecover: Root was killed!")
+	}
+	verifyCascadeEndState(t, child, true, 0, true, 0, false, 0, true)
+
+	parent.SetRecover(false)
+	if parent.getPanicPolicy() != PanicCrash {
+		t.Error("SetRecover: Disabling should restore PanicCrash!")
+	}
+	cas.Kill()
+}
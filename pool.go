@@ -0,0 +1,88 @@
+package cascade
+
+// Pool runs submitted tasks on a fixed number of worker goroutines. Every worker is tracked by the Cascade
+// of the Pool (see `Cascade`), which is a child of the Cascade the Pool was created from, so the Pool shuts
+// down along with it.
+//
+// When the Pool is killed the tasks that are still queued are run before it is dead, when it is cancelled
+// they are dropped.
+type Pool struct {
+	cas   *Cascade
+	tasks chan func()
+}
+
+// NewPool creates a Pool with the provided number of workers as a child of the Cascade. The queue holds as
+// many tasks as there are workers. A workers value below 1 is treated as 1.
+func (c *Cascade) NewPool(workers int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &Pool{
+		cas:   c.ChildCascade(),
+		tasks: make(chan func(), workers),
+	}
+	// Catches tasks that were queued while the workers were draining the queue
+	p.cas.DoOnKill(p.drain)
+	for i := 0; i < workers && p.cas.Mark(); i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Cascade returns the Cascade of the Pool, it can be used to kill or cancel the Pool on its own.
+func (p *Pool) Cascade() *Cascade {
+	return p.cas
+}
+
+// Submit queues a task to be run by one of the workers. It blocks while the queue is full and returns
+// `ErrPoolDying` if the Pool has started shutting down, in which case the task will not be run.
+func (p *Pool) Submit(task func()) error {
+	select {
+	case <-p.cas.Dying():
+		return ErrPoolDying
+	default:
+	}
+	select {
+	case <-p.cas.Dying():
+		return ErrPoolDying
+	case p.tasks <- task:
+		return nil
+	}
+}
+
+// Runs tasks until the Pool is dying, then works through the queue unless the Pool was cancelled
+func (p *Pool) work() {
+	defer p.cas.UnMark()
+	for {
+		select {
+		case <-p.cas.Dying(): // Checked first so that a cancel is not ignored while tasks are queued
+			if !p.cas.isCancelled() {
+				p.drain()
+			}
+			return
+		default:
+		}
+		select {
+		case task := <-p.tasks:
+			p.run(task)
+		case <-p.cas.Dying():
+		}
+	}
+}
+
+// Runs queued tasks until the queue is empty
+func (p *Pool) drain() {
+	for {
+		select {
+		case task := <-p.tasks:
+			p.run(task)
+		default:
+			return
+		}
+	}
+}
+
+func (p *Pool) run(task func()) {
+	defer p.cas.handlePanic()
+	task()
+}
@@ -0,0 +1,87 @@
+package cascade
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPool_Submit(t *testing.T) {
+	cas := RootCascade()
+	pool := cas.NewPool(4)
+	var ran atomic.Int32
+	for i := 0; i < 20; i++ {
+		if err := pool.Submit(func() { ran.Add(1) }); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The parent's actions run only once the Pool, and its queue, is done
+	var atKill int32
+	cas.DoOnKill(func() { atKill = ran.Load() })
+	cas.Kill()
+	if atKill != 20 {
+		t.Errorf("Submit: Expected 20 tasks to have run before the parent's actions, got %d", atKill)
+	}
+	if pool.Submit(func() {}) != ErrPoolDying {
+		t.Error("Submit: Expected an error once the Pool is dead!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 1, false, 0, false)
+}
+
+func TestPool_Backpressure(t *testing.T) {
+	cas := RootCascade()
+	pool := cas.NewPool(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = pool.Submit(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	_ = pool.Submit(func() {}) // Fills the queue
+
+	submitted := make(chan error)
+	go func() { submitted <- pool.Submit(func() {}) }()
+	select {
+	case <-submitted:
+		t.Error("Backpressure: Submit should block while the queue is full!")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case err := <-submitted:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Backpressure: Submit should unblock once the queue has room!")
+	}
+	cas.Kill()
+}
+
+func TestPool_Cancel(t *testing.T) {
+	cas := RootCascade()
+	pool := cas.NewPool(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = pool.Submit(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	var queuedRan atomic.Bool
+	_ = pool.Submit(func() { queuedRan.Store(true) })
+
+	go func() {
+		<-pool.Cascade().Dying()
+		close(release)
+	}()
+	cas.Cancel()
+	if queuedRan.Load() {
+		t.Error("Cancel: Queued tasks should be dropped!")
+	}
+	if pool.Cascade().Alive() {
+		t.Error("Cancel: Pool should be dead!")
+	}
+}
@@ -0,0 +1,49 @@
+package cascade
+
+import (
+	"os"
+	"os/signal"
+)
+
+// NotifyOnSignal starts a goroutine that kills the Cascade (see `Kill`) when the process receives one
+// of the provided signals. This is meant to be used on the `RootCascade` of command line tools, to shut down
+// cleanly on `os.Interrupt` or `syscall.SIGTERM`.
+//
+// Signals that are already registered on the Cascade by an earlier call are ignored, so calling NotifyOnSignal
+// twice with the same signals only registers them once. The signals stop being delivered (see `signal.Stop`)
+// once one of them is received or the Cascade starts dying for any other reason.
+//
+// The current Cascade is returned to allow chaining.
+func (c *Cascade) NotifyOnSignal(sigs ...os.Signal) *Cascade {
+	c.muConfig.Lock()
+	if c.signals == nil {
+		c.signals = make(map[os.Signal]struct{})
+	}
+	fresh := make([]os.Signal, 0, len(sigs))
+	for _, sig := range sigs {
+		if _, ok := c.signals[sig]; !ok {
+			c.signals[sig] = struct{}{}
+			fresh = append(fresh, sig)
+		}
+	}
+	c.muConfig.Unlock()
+	if len(fresh) == 0 {
+		return c
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, fresh...)
+	c.markWatcher()
+	go func() {
+		select {
+		case <-received:
+			signal.Stop(received)
+			c.unmarkWatcher() // Kill waits for watchers, so this one has to be released first
+			c.Kill()
+		case <-c.Dying():
+			signal.Stop(received)
+			c.unmarkWatcher()
+		}
+	}()
+	return c
+}
@@ -0,0 +1,49 @@
+//go:build !windows
+
+package cascade
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCascade_NotifyOnSignal(t *testing.T) {
+	cas := RootCascade()
+	if cas.NotifyOnSignal(syscall.SIGTERM) != cas {
+		t.Error("NotifyOnSignal: Should return the Cascade!")
+	}
+	cas.NotifyOnSignal(syscall.SIGTERM)
+	if cas.TrackedCount() != 0 || cas.watchers.Load() != 1 {
+		t.Errorf("NotifyOnSignal: Expected 1 watcher and no tracked goroutines, got %d tracked", cas.TrackedCount())
+	}
+
+	self, _ := os.FindProcess(os.Getpid())
+	if err := self.Signal(syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	if !didExitBeforeTime(cas, time.Second) {
+		t.Fatal("NotifyOnSignal: Signal should kill the Cascade!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+
+	cas = RootCascade()
+	cas.NotifyOnSignal(syscall.SIGTERM)
+	cas.Kill()
+	if cas.watchers.Load() != 0 {
+		t.Error("NotifyOnSignal: Goroutine should exit when the Cascade dies!")
+	}
+}
+
+func TestCascade_DrainNotifyOnSignal(t *testing.T) {
+	cas := RootCascade().NotifyOnSignal(syscall.SIGTERM)
+	cas.Go(func(cc *Cascade) { <-time.After(10 * time.Millisecond) })
+
+	if !drainedBeforeTime(cas, time.Second) {
+		t.Fatal("Drain: Should not wait for the signal watcher!")
+	}
+	if cas.watchers.Load() != 0 {
+		t.Error("Drain: Signal watcher should exit once the Cascade is killed!")
+	}
+}
@@ -0,0 +1,97 @@
+package cascade
+
+// State is a stage in the lifecycle of a Cascade, see `OnStateChange`.
+type State int
+
+const (
+	// StateAlive means that the Cascade has not been killed or cancelled.
+	StateAlive State = iota
+	// StateDying means that the Cascade is dying (see `Dying`), all of its children are done.
+	StateDying
+	// StateDead means that the Cascade is dead (see `Dead`), all of its tracked goroutines have exited.
+	StateDead
+	// StateDone means that the Cascade is done (see `Done`), all of its actions have been run.
+	StateDone
+)
+
+func (s State) String() string {
+	switch s {
+	case StateAlive:
+		return "alive"
+	case StateDying:
+		return "dying"
+	case StateDead:
+		return "dead"
+	case StateDone:
+		return "done"
+	default:
+		return "unknown state"
+	}
+}
+
+// OnStateChange registers a hook that is called every time the Cascade moves to the next `State` while it is
+// being killed or cancelled: once with `StateDying`, once with `StateDead` and once with `StateDone`. Every
+// Cascade starts out in `StateAlive`, so that state is never reported. Hooks are called in the order they were
+// registered.
+//
+// Hooks are called synchronously by the teardown, `StateDone` is reported right before `Done` is closed.
+// Hooks MUST NOT block and MUST NOT kill, cancel or wait on the Cascade (or its ancestors).
+//
+// Hooks registered after a transition has happened are not called for it.
+func (c *Cascade) OnStateChange(hook func(state State)) {
+	c.muActions.Lock()
+	c.stateHooks = append(c.stateHooks, hook)
+	c.muActions.Unlock()
+}
+
+// Calls the hooks registered with OnStateChange
+func (c *Cascade) notifyState(state State) {
+	c.muActions.Lock()
+	hooks := c.stateHooks
+	c.muActions.Unlock()
+	for _, hook := range hooks {
+		hook(state)
+	}
+}
+
+// CascadeState is a point-in-time view of a Cascade, see `Snapshot`.
+type CascadeState struct {
+	Tracked         int   // Number of goroutines being tracked, see `TrackedCount`
+	PeakTracked     int   // Highest number of goroutines tracked at the same time, see `PeakTracked`
+	ChildCount      int   // Number of children
+	ActionCount     int   // Number of kill actions that are registered, see `DoOnKill`
+	IsDead          bool  // See `IsDead`
+	HasContext      bool  // Whether a Context is linked to the Cascade, see `WithContext`
+	TrackedContexts int   // Number of Contexts handed out by `Context` (and its variants) that are tracked
+	Err             error // The error set on the Cascade, see `Cause`
+}
+
+// Snapshot returns the state of the Cascade. Every lock of the Cascade is held while the state is read, so the
+// fields are consistent with each other.
+//
+// Err only holds an error that was set on the Cascade rather than `ErrKilled` or `ErrCancelled`, IsDead tells
+// whether the Cascade has been killed or cancelled.
+func (c *Cascade) Snapshot() CascadeState {
+	c.muChildren.Lock()
+	defer c.muChildren.Unlock()
+	c.muActions.Lock()
+	defer c.muActions.Unlock()
+	c.muTracked.Lock()
+	defer c.muTracked.Unlock()
+	c.muDead.RLock()
+	defer c.muDead.RUnlock()
+	c.muCtx.Lock()
+	defer c.muCtx.Unlock()
+	c.muErr.Lock()
+	defer c.muErr.Unlock()
+	return CascadeState{
+		Tracked:         int(c.tracked.Load()),
+		PeakTracked:     int(c.peakTracked.Load()),
+		ChildCount:      len(c.children),
+		ActionCount:     len(c.actions.actions),
+		IsDead:          c.isDead.Load(),
+		HasContext:      c.ctx != nil,
+		TrackedContexts: len(c.trackedCtx),
+		Err:             c.err,
+	}
+}
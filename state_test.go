@@ -0,0 +1,70 @@
+package cascade
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCascade_OnStateChange(t *testing.T) {
+	for _, stop := range []func(*Cascade){(*Cascade).Kill, (*Cascade).Cancel} {
+		cas := RootCascade()
+		states := make([]State, 0)
+		order := make([]int, 0)
+		cas.OnStateChange(func(state State) {
+			states = append(states, state)
+			order = append(order, 1)
+		})
+		cas.OnStateChange(func(state State) { order = append(order, 2) })
+
+		stop(cas)
+		expected := []State{StateDying, StateDead, StateDone}
+		if len(states) != len(expected) {
+			t.Fatalf("OnStateChange: Expected %v, got %v", expected, states)
+		}
+		for i, state := range expected {
+			if states[i] != state {
+				t.Errorf("OnStateChange: Expected %v, got %v", expected, states)
+			}
+			if order[2*i] != 1 || order[2*i+1] != 2 {
+				t.Error("OnStateChange: Hooks should run in registration order!")
+			}
+		}
+	}
+}
+
+func TestCascade_Snapshot(t *testing.T) {
+	cas, _ := WithContext(context.Background())
+	cas.ChildCascade()
+	cas.ChildCascade()
+	cas.DoOnKill(func() {})
+	cas.DoOnKill(func() {})
+	cas.DoOnCancel(func() {}) // Not a kill action
+	cas.Mark()
+	cas.Mark()
+	cas.UnMark()
+	cas.Context(context.TODO())
+	failure := errors.New("failure")
+	cas.AddError(failure)
+
+	expected := CascadeState{
+		Tracked:         1,
+		PeakTracked:     2,
+		ChildCount:      2,
+		ActionCount:     2,
+		IsDead:          false,
+		HasContext:      true,
+		TrackedContexts: 2, // The Context of WithContext and the one derived from context.TODO
+		Err:             failure,
+	}
+	if state := cas.Snapshot(); state != expected {
+		t.Errorf("Snapshot: Expected %+v, got %+v", expected, state)
+	}
+
+	cas.UnMark()
+	cas.Kill()
+	expected = CascadeState{PeakTracked: 2, ActionCount: 2, IsDead: true, HasContext: true, Err: failure}
+	if state := cas.Snapshot(); state != expected {
+		t.Errorf("Snapshot: Expected %+v, got %+v", expected, state)
+	}
+}
@@ -0,0 +1,52 @@
+package cascade
+
+import (
+	"time"
+)
+
+// RestartPolicy limits how often `Supervise` restarts a function.
+type RestartPolicy struct {
+	MaxRestarts int           // Number of restarts allowed within Window
+	Window      time.Duration // Restarts older than this no longer count, 0 counts every restart
+}
+
+// Supervise runs the provided function as a tracked goroutine (just like `Go`) and restarts it whenever it
+// returns while the Cascade is still alive. A function that returns because the Cascade was killed or
+// cancelled is not restarted.
+//
+// Once the function has been restarted `MaxRestarts` times within the `Window` of the policy, it is no longer
+// restarted and the returned Cascade is killed with `ErrTooManyRestarts` (see `KillWithError`).
+//
+// The returned Cascade is a child of the current Cascade that is tracking the provided function.
+func (c *Cascade) Supervise(f func(*Cascade), policy RestartPolicy) *Cascade {
+	child := c.ChildCascade()
+	child.launch(func() { child.supervise(f, policy) })
+	return child
+}
+
+// Runs and restarts the function according to the policy, see Supervise
+func (c *Cascade) supervise(f func(*Cascade), policy RestartPolicy) {
+	restarts := make([]time.Time, 0)
+	for {
+		c.Wrap(f)
+		if c.IsDead() {
+			return
+		}
+		now := time.Now()
+		if policy.Window > 0 {
+			recent := restarts[:0]
+			for _, at := range restarts {
+				if now.Sub(at) < policy.Window {
+					recent = append(recent, at)
+				}
+			}
+			restarts = recent
+		}
+		if len(restarts) >= policy.MaxRestarts {
+			// Killing the Cascade waits for this goroutine, so it can't be done synchronously
+			go c.KillWithError(ErrTooManyRestarts)
+			return
+		}
+		restarts = append(restarts, now)
+	}
+}
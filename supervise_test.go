@@ -0,0 +1,49 @@
+package cascade
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCascade_Supervise(t *testing.T) {
+	cas := RootCascade()
+	var runs atomic.Int32
+	child := cas.Supervise(func(cc *Cascade) { runs.Add(1) }, RestartPolicy{MaxRestarts: 3})
+
+	if !didExitBeforeTime(child, time.Second) {
+		t.Fatal("Supervise: Exhausted policy should kill the Cascade!")
+	}
+	if runs.Load() != 4 {
+		t.Errorf("Supervise: Expected 1 run and 3 restarts, got %d runs", runs.Load())
+	}
+	if child.Error() != ErrTooManyRestarts {
+		t.Errorf("Supervise: Expected %v, got %v", ErrTooManyRestarts, child.Error())
+	}
+	if !cas.Alive() {
+		t.Error("Supervise: Parent should still be alive!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_SuperviseWindow(t *testing.T) {
+	cas := RootCascade()
+	var runs atomic.Int32
+	child := cas.Supervise(func(cc *Cascade) {
+		if runs.Add(1) <= 4 {
+			time.Sleep(15 * time.Millisecond) // Slow crashes stay within the policy
+			return
+		}
+		cc.Hold()
+	}, RestartPolicy{MaxRestarts: 1, Window: 10 * time.Millisecond})
+
+	time.Sleep(100 * time.Millisecond)
+	if !child.Alive() || runs.Load() != 5 {
+		t.Errorf("SuperviseWindow: Expected 5 runs on a live Cascade, got %d", runs.Load())
+	}
+	cas.Kill()
+	if runs.Load() != 5 {
+		t.Error("SuperviseWindow: Should not restart when killed!")
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
@@ -0,0 +1,428 @@
+package cascade
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// orphans counts the children created after the teardown of their parent had reached its children, see OrphanCount
+var orphans atomic.Int64
+
+// unnamedPathElement is used in place of the name of an unnamed Cascade when building a `Path`.
+const unnamedPathElement = "-"
+
+// Parent returns the Cascade that the current Cascade is a child of, or `nil` for a `RootCascade`.
+func (c *Cascade) Parent() *Cascade {
+	return c.parent.Load()
+}
+
+// Root returns the `RootCascade` at the top of the tree that the Cascade belongs to, which is the Cascade itself
+// if it is a root.
+//
+// A Cascade that is detached while Root is walking up (see `Detach`) may or may not be treated as the root.
+func (c *Cascade) Root() *Cascade {
+	node := c
+	for parent := node.Parent(); parent != nil; parent = node.Parent() {
+		node = parent
+	}
+	return node
+}
+
+// IsRoot returns `true` if the Cascade has no parent, for example one created with `RootCascade`.
+func (c *Cascade) IsRoot() bool {
+	return c.Parent() == nil
+}
+
+// Depth returns the number of ancestors between the Cascade and its root, a `RootCascade` has a depth of 0.
+func (c *Cascade) Depth() int {
+	depth := 0
+	for node := c.Parent(); node != nil; node = node.Parent() {
+		depth++
+	}
+	return depth
+}
+
+// Detach removes the Cascade from its parent and turns it into a root (see `IsRoot`), so that it and its
+// descendants are no longer killed or cancelled along with the old parent. The Cascade keeps the configuration
+// it inherited from the parent. The detached Cascade is returned.
+//
+// An error is returned, and nothing is changed, if the Cascade is already a root or if the Cascade or its
+// parent is already dead.
+func (c *Cascade) Detach() (*Cascade, error) {
+	parent := c.Parent()
+	if parent == nil {
+		return nil, errors.New("cascade: already a root")
+	}
+	if c.IsDead() {
+		return nil, errors.New("cascade: already dead")
+	}
+	parent.muChildren.Lock()
+	defer parent.muChildren.Unlock()
+	// The parent takes its snapshot of the children after being marked as dead
+	if parent.IsDead() {
+		return nil, errors.New("cascade: parent is already dead")
+	}
+	if _, ok := parent.children[c]; !ok {
+		return nil, errors.New("cascade: already detached")
+	}
+	delete(parent.children, c)
+	parent.notifyChildEvent(ChildRemoved, c)
+	c.parent.Store(nil)
+	return c, nil
+}
+
+// Children returns the current children of the Cascade in the order they were created. The returned slice is a
+// copy that can be freely modified, it is empty (but never `nil`) if the Cascade has no children.
+//
+// Children are removed once they are done, so the slice only reflects the tree at the time of the call.
+func (c *Cascade) Children() []*Cascade {
+	return c.childSnapshot()
+}
+
+// ChildCount returns the number of children that the Cascade currently has.
+func (c *Cascade) ChildCount() int {
+	c.muChildren.Lock()
+	defer c.muChildren.Unlock()
+	return len(c.children)
+}
+
+// KillChildren kills every current child of the Cascade (just like `Kill`) without killing the Cascade itself.
+// The Cascade stays alive and new children can still be created with `ChildCascade`.
+//
+// Children created while KillChildren is running are not killed.
+//
+// Note: This function blocks until all of the children have finished exiting.
+func (c *Cascade) KillChildren() {
+	c.stopChildren(modeKill, c.childSnapshot())
+}
+
+// CancelChildren cancels every current child of the Cascade (just like `Cancel`) without cancelling the Cascade
+// itself. See `KillChildren`.
+//
+// Note: This function blocks until all of the children have finished exiting.
+func (c *Cascade) CancelChildren() {
+	c.stopChildren(modeCancel, c.childSnapshot())
+}
+
+// Ancestors returns the chain of Cascades starting with the current Cascade and ending with the `RootCascade`.
+//
+// The returned slice always contains at least the current Cascade.
+func (c *Cascade) Ancestors() []*Cascade {
+	ancestors := make([]*Cascade, 0, 1)
+	for node := c; node != nil; node = node.Parent() {
+		ancestors = append(ancestors, node)
+	}
+	return ancestors
+}
+
+// Path returns the names of every Cascade from the `RootCascade` down to the current Cascade joined by "/".
+// For example: "root/ingress/worker-3"
+//
+// Cascades that have not been named (see `SetName`) are represented by "-".
+func (c *Cascade) Path() string {
+	ancestors := c.Ancestors()
+	names := make([]string, len(ancestors))
+	for i, node := range ancestors {
+		name := node.Name()
+		if name == "" {
+			name = unnamedPathElement
+		}
+		names[len(ancestors)-1-i] = name
+	}
+	return strings.Join(names, "/")
+}
+
+// ChildEvent describes a change to the children of a Cascade. See `WaitChildEvent`.
+type ChildEvent int
+
+const (
+	// ChildAdded means that a new child Cascade was created.
+	ChildAdded ChildEvent = iota
+	// ChildRemoved means that a child Cascade finished and was removed from its parent.
+	ChildRemoved
+	// CascadeDone means that the Cascade itself is done and its children will not change anymore.
+	CascadeDone
+)
+
+func (e ChildEvent) String() string {
+	switch e {
+	case ChildAdded:
+		return "child added"
+	case ChildRemoved:
+		return "child removed"
+	case CascadeDone:
+		return "cascade done"
+	default:
+		return "unknown child event"
+	}
+}
+
+type childEvent struct {
+	event ChildEvent
+	child *Cascade
+}
+
+// WaitChildEvent blocks until the next child of the Cascade is added or removed and returns what happened
+// along with the affected child.
+//
+// The teardown of the Cascade releases its children right before `Dying` is closed, after which they no
+// longer change. If that has already happened, or the Cascade becomes done while waiting, `CascadeDone` is
+// returned with a `nil` child. A Cascade that is dead but still killing its children keeps reporting
+// their removal.
+//
+// Only events that happen after the call are reported, so a supervisor that needs to keep track of every
+// change should re-check the state it cares about before waiting again.
+func (c *Cascade) WaitChildEvent() (ChildEvent, *Cascade) {
+	waiter := make(chan childEvent, 1)
+	c.muChildren.Lock()
+	if c.children == nil { // Released by closeAndClean
+		c.muChildren.Unlock()
+		return CascadeDone, nil
+	}
+	c.childWait = append(c.childWait, waiter)
+	c.muChildren.Unlock()
+
+	select {
+	case ev := <-waiter:
+		return ev.event, ev.child
+	case <-c.done:
+		return CascadeDone, nil
+	}
+}
+
+// Sends an event to every caller of WaitChildEvent. The caller must hold muChildren.
+func (c *Cascade) notifyChildEvent(event ChildEvent, child *Cascade) {
+	for _, waiter := range c.childWait {
+		waiter <- childEvent{event, child}
+	}
+	c.childWait = nil
+}
+
+// OrphanCount returns the number of Cascades in the process that were orphaned: children created on a parent
+// whose teardown had already gone past its children, so that the parent never tears them down itself.
+//
+// Orphans are not leaked, `ChildCascade` kills or cancels them as they are created and nothing is launched
+// on them (see `Go`). Creating them is still a sign of misuse, a growing count means that some code keeps
+// using Cascades after they died.
+func OrphanCount() int64 {
+	return orphans.Load()
+}
+
+// KillCount returns the number of Cascades that have been killed in the tree that the Cascade belongs to,
+// including the root itself (see `Root`). Cascades that are killed because their parent was killed are
+// counted as well.
+func (c *Cascade) KillCount() int64 {
+	return c.Root().kills.Load()
+}
+
+// CancelCount returns the number of Cascades that have been cancelled in the tree that the Cascade belongs
+// to, see `KillCount`.
+func (c *Cascade) CancelCount() int64 {
+	return c.Root().cancels.Load()
+}
+
+// Counts a Cascade of the tree that was killed or cancelled, called on the root
+func (c *Cascade) countDeath(mode teardownMode) {
+	if mode == modeKill {
+		c.kills.Add(1)
+	} else {
+		c.cancels.Add(1)
+	}
+}
+
+// NodeInfo describes a single Cascade at the time `Flatten` was called.
+type NodeInfo struct {
+	Index    int    // Position of the node in the slice returned by Flatten
+	Parent   int    // Index of the parent node, or -1 for the Cascade that Flatten was called on
+	ParentID string // ID of the parent Cascade (see `Parent`), or "" for a root Cascade
+	ID       string // See `ID`
+	Name     string // See `Name`
+	Tracked  int    // Number of goroutines being tracked
+	Dead     bool   // See `IsDead`
+	Err      error  // See `Error`
+}
+
+// Flatten returns a snapshot of the Cascade and all of its descendants as a flat slice, in depth-first order,
+// starting with the current Cascade.
+//
+// The returned slice does not reference the live tree so it is safe to keep around, render or compare.
+// Descendants that are created or removed while Flatten is running may or may not be included.
+func (c *Cascade) Flatten() []NodeInfo {
+	nodes := make([]NodeInfo, 0)
+	var visit func(node *Cascade, parent int)
+	visit = func(node *Cascade, parent int) {
+		tracked := node.TrackedCount()
+		parentID := ""
+		if parent >= 0 {
+			parentID = nodes[parent].ID
+		} else if p := node.Parent(); p != nil {
+			parentID = p.ID()
+		}
+		index := len(nodes)
+		nodes = append(nodes, NodeInfo{
+			Index:    index,
+			Parent:   parent,
+			ParentID: parentID,
+			ID:       node.ID(),
+			Name:     node.Name(),
+			Tracked:  tracked,
+			Dead:     node.IsDead(),
+			Err:      node.Error(),
+		})
+		for _, child := range node.childSnapshot() {
+			visit(child, index)
+		}
+	}
+	visit(c, -1)
+	return nodes
+}
+
+// Tree renders the Cascade and all of its descendants as an indented text tree, one Cascade per line, for
+// example:
+//
+//	root tracked=0 children=1 alive
+//	  worker-pool tracked=2 children=0 dead err="cascade: killed"
+//
+// Each line shows the name of the Cascade ("-" if it has not been named), the number of tracked goroutines,
+// the number of children, its state and its error if there is one (see `Error`).
+//
+// Tree is built from a snapshot (see `Flatten`), so it is safe to call while the tree is being torn down,
+// for example to find out why a `Kill` is hanging. Children that are removed while walking the tree are left
+// out.
+func (c *Cascade) Tree() string {
+	nodes := c.Flatten()
+	depth := make([]int, len(nodes))
+	children := make([]int, len(nodes))
+	for i, node := range nodes {
+		if node.Parent >= 0 {
+			depth[i] = depth[node.Parent] + 1
+			children[node.Parent]++
+		}
+	}
+
+	b := strings.Builder{}
+	for i, node := range nodes {
+		name := node.Name
+		if name == "" {
+			name = unnamedPathElement
+		}
+		state := "alive"
+		if node.Dead {
+			state = "dead"
+		}
+		b.WriteString(strings.Repeat("  ", depth[i]))
+		fmt.Fprintf(&b, "%s tracked=%d children=%d %s", name, node.Tracked, children[i], state)
+		if node.Err != nil {
+			fmt.Fprintf(&b, " err=%q", node.Err.Error())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// WaitAllDescendantsDone blocks until every descendant of the Cascade is completely done (see `WaitDone`).
+// It does not kill or cancel anything, and it does not wait for the current Cascade itself.
+//
+// The descendants are collected once when WaitAllDescendantsDone is called, no locks are held while waiting.
+// Descendants that are already done have been removed from the tree and so are not waited on, and descendants
+// created while waiting are not waited on either.
+func (c *Cascade) WaitAllDescendantsDone() {
+	done := make([]<-chan struct{}, 0)
+	var collect func(node *Cascade)
+	collect = func(node *Cascade) {
+		for _, child := range node.childSnapshot() {
+			done = append(done, child.done)
+			collect(child)
+		}
+	}
+	collect(c)
+	for _, ch := range done {
+		<-ch
+	}
+}
+
+// KillCollect kills the Cascade (just like `Kill`) and, once it is done, returns every error set anywhere in
+// its subtree, including the Cascade itself. Each error is a `*NodeError` that carries the `Path` of the
+// Cascade the error was set on, in breadth-first order.
+//
+// The subtree is collected before the kill, since done children are removed from the tree. Descendants
+// created while the Cascade is being killed are not included.
+//
+// Note: This function blocks until all children and the current Cascade have finished exiting.
+func (c *Cascade) KillCollect() []error {
+	nodes := []*Cascade{c}
+	for i := 0; i < len(nodes); i++ {
+		nodes = append(nodes, nodes[i].childSnapshot()...)
+	}
+	c.Kill()
+	errs := make([]error, 0)
+	for _, node := range nodes {
+		if err := node.explicitError(); err != nil {
+			errs = append(errs, &NodeError{Path: node.Path(), Err: err})
+		}
+	}
+	return errs
+}
+
+// collectedErrors holds the errors collected from the subtree of a child that has been removed.
+type collectedErrors struct {
+	id   uint64 // ID of the removed child, used for ordering
+	errs []error
+}
+
+// CollectErrors returns every error set anywhere in the subtree of the Cascade, including the Cascade itself.
+// The errors are returned in depth-first order: the error of a Cascade comes before the errors of its
+// children, and children are visited in the order they were created.
+//
+// Done children are removed from the tree, but their errors are kept by their parent, so CollectErrors is
+// safe to call (and still complete) after the whole tree is dead. Errors are only ever set explicitly (for
+// example with `KillWithError`), `ErrKilled` and `ErrCancelled` are never included.
+//
+// Note: The errors of removed children are kept for as long as the parent is, a long-lived Cascade whose
+// children keep failing will accumulate them.
+func (c *Cascade) CollectErrors() []error {
+	errs := make([]error, 0)
+	if err := c.explicitError(); err != nil {
+		errs = append(errs, err)
+	}
+
+	c.muChildren.Lock()
+	entries := make([]collectedErrors, 0, len(c.children)+len(c.childErrs))
+	entries = append(entries, c.childErrs...)
+	children := make([]*Cascade, 0, len(c.children))
+	for child := range c.children {
+		children = append(children, child)
+	}
+	c.muChildren.Unlock()
+
+	// The children are visited after releasing the lock to keep the lock order
+	for _, child := range children {
+		entries = append(entries, collectedErrors{id: child.id, errs: child.CollectErrors()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].id < entries[j].id })
+	for _, entry := range entries {
+		errs = append(errs, entry.errs...)
+	}
+	return errs
+}
+
+// CollectErrorsJoined returns the errors from `CollectErrors` wrapped with `errors.Join`, or `nil` if there are no
+// errors in the subtree.
+func (c *Cascade) CollectErrorsJoined() error {
+	return errors.Join(c.CollectErrors()...)
+}
+
+// Returns a copy of the current children of the Cascade, in the order they were created
+func (c *Cascade) childSnapshot() []*Cascade {
+	c.muChildren.Lock()
+	children := make([]*Cascade, 0, len(c.children))
+	for child := range c.children {
+		children = append(children, child)
+	}
+	c.muChildren.Unlock()
+	sort.Slice(children, func(i, j int) bool { return children[i].id < children[j].id })
+	return children
+}
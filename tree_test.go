@@ -0,0 +1,522 @@
+package cascade
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCascade_Ancestors(t *testing.T) {
+	cas := RootCascade()
+	child1 := cas.ChildCascade()
+	child2 := child1.ChildCascade()
+
+	ancestors := child2.Ancestors()
+	if len(ancestors) != 3 {
+		t.Fatalf("Ancestors: Expected 3 Cascades, got %v", len(ancestors))
+	}
+	if ancestors[0] != child2 || ancestors[1] != child1 || ancestors[2] != cas {
+		t.Error("Ancestors: Chain was not in order from the Cascade to the root!")
+	}
+
+	ancestors = cas.Ancestors()
+	if len(ancestors) != 1 || ancestors[0] != cas {
+		t.Error("Ancestors: Root should only contain itself!")
+	}
+
+	cas.Kill()
+}
+
+func TestCascade_Path(t *testing.T) {
+	cas := RootCascade()
+	cas.SetName("root")
+	ingress := cas.ChildCascade()
+	ingress.SetName("ingress")
+	unnamed := ingress.ChildCascade()
+	worker := unnamed.ChildCascade()
+	worker.SetName("worker-3")
+
+	if path := cas.Path(); path != "root" {
+		t.Errorf("Path: Expected %q, got %q", "root", path)
+	}
+	if path := ingress.Path(); path != "root/ingress" {
+		t.Errorf("Path: Expected %q, got %q", "root/ingress", path)
+	}
+	if path := worker.Path(); path != "root/ingress/-/worker-3" {
+		t.Errorf("Path: Expected %q, got %q", "root/ingress/-/worker-3", path)
+	}
+
+	cas.Kill()
+}
+
+func TestCascade_WaitChildEvent(t *testing.T) {
+	cas := RootCascade()
+
+	type result struct {
+		event ChildEvent
+		child *Cascade
+	}
+	wait := func() <-chan result {
+		ch := make(chan result, 1)
+		go func() {
+			event, child := cas.WaitChildEvent()
+			ch <- result{event, child}
+		}()
+		// Make sure that the waiter is registered before continuing.
+		for {
+			cas.muChildren.Lock()
+			n := len(cas.childWait)
+			cas.muChildren.Unlock()
+			if n > 0 {
+				return ch
+			}
+			<-time.After(time.Millisecond)
+		}
+	}
+
+	events := wait()
+	child := cas.ChildCascade()
+	select {
+	case res := <-events:
+		if res.event != ChildAdded || res.child != child {
+			t.Errorf("WaitChildEvent: Expected %v for the new child, got %v", ChildAdded, res.event)
+		}
+	case <-time.After(time.Second):
+		t.Error("WaitChildEvent: Did not see the child being added!")
+	}
+
+	events = wait()
+	child.Kill()
+	select {
+	case res := <-events:
+		if res.event != ChildRemoved || res.child != child {
+			t.Errorf("WaitChildEvent: Expected %v for the killed child, got %v", ChildRemoved, res.event)
+		}
+	case <-time.After(time.Second):
+		t.Error("WaitChildEvent: Did not see the child being removed!")
+	}
+
+	events = wait()
+	go cas.Kill()
+	select {
+	case res := <-events:
+		if res.event != CascadeDone || res.child != nil {
+			t.Errorf("WaitChildEvent: Expected %v, got %v", CascadeDone, res.event)
+		}
+	case <-time.After(time.Second):
+		t.Error("WaitChildEvent: Did not return once the Cascade was done!")
+	}
+
+	if event, child := cas.WaitChildEvent(); event != CascadeDone || child != nil {
+		t.Errorf("WaitChildEvent: Expected %v on a dead Cascade, got %v", CascadeDone, event)
+	}
+}
+
+func TestCascade_Flatten(t *testing.T) {
+	cas := RootCascade()
+	cas.SetName("root")
+	child1 := cas.ChildCascade()
+	child1.SetName("child1")
+	child2 := cas.ChildCascade()
+	child2.SetName("child2")
+	grandchild := child1.ChildCascade()
+	grandchild.SetName("grandchild")
+
+	grandchild.Mark()
+	err := errors.New("child2")
+	child2.muErr.Lock()
+	child2.err = err
+	child2.muErr.Unlock()
+
+	nodes := cas.Flatten()
+	if len(nodes) != 4 {
+		t.Fatalf("Flatten: Expected 4 nodes, got %v", len(nodes))
+	}
+
+	byName := make(map[string]NodeInfo)
+	for i, node := range nodes {
+		if node.Index != i {
+			t.Errorf("Flatten: Node %q has index %v at position %v", node.Name, node.Index, i)
+		}
+		if node.Parent >= i {
+			t.Errorf("Flatten: Node %q appears before its parent", node.Name)
+		}
+		byName[node.Name] = node
+	}
+
+	if nodes[0].Name != "root" || nodes[0].Parent != -1 {
+		t.Error("Flatten: First node should be the Cascade itself!")
+	}
+	if nodes[byName["child1"].Parent].Name != "root" || nodes[byName["child2"].Parent].Name != "root" {
+		t.Error("Flatten: Children should have the root as parent!")
+	}
+	if nodes[byName["grandchild"].Parent].Name != "child1" {
+		t.Error("Flatten: Grandchild should have child1 as parent!")
+	}
+	if byName["grandchild"].Tracked != 1 {
+		t.Errorf("Flatten: Expected grandchild to track 1 goroutine, got %v", byName["grandchild"].Tracked)
+	}
+	if byName["grandchild"].ID != grandchild.ID() {
+		t.Errorf("Flatten: Expected grandchild ID %v, got %v", grandchild.ID(), byName["grandchild"].ID)
+	}
+	if nodes[0].ParentID != "" || byName["grandchild"].ParentID != child1.ID() {
+		t.Error("Flatten: Expected ParentID to match the ID of the parent!")
+	}
+	if sub := child1.Flatten(); sub[0].ParentID != cas.ID() {
+		t.Errorf("Flatten: Expected child1 ParentID %v, got %v", cas.ID(), sub[0].ParentID)
+	}
+	if byName["child2"].Err != err {
+		t.Errorf("Flatten: Expected child2 error %v, got %v", err, byName["child2"].Err)
+	}
+	for _, node := range nodes {
+		if node.Dead {
+			t.Errorf("Flatten: Node %q should not be dead", node.Name)
+		}
+	}
+
+	grandchild.UnMark()
+	cas.Kill()
+	if nodes := cas.Flatten(); len(nodes) != 1 || !nodes[0].Dead {
+		t.Error("Flatten: Expected a single dead node after Kill!")
+	}
+}
+
+func TestOrphanCount(t *testing.T) {
+	cas := RootCascade()
+	cas.Kill()
+
+	before := OrphanCount()
+	child := cas.ChildCascade()
+	if OrphanCount() != before+1 {
+		t.Errorf("OrphanCount: Expected %v orphans, got %v", before+1, OrphanCount())
+	}
+	if child.Parent() != cas {
+		t.Error("OrphanCount: Orphan should still know its parent!")
+	}
+	if !child.IsDead() || !child.WaitDoneWithTimeout(time.Second) {
+		t.Error("OrphanCount: Orphan should be torn down as it is created!")
+	}
+}
+
+func TestCascade_ChildCascadeDeadParent(t *testing.T) {
+	cas := RootCascade()
+	var dying *Cascade
+	cas.DoOnKill(func() { dying = cas.ChildCascade() }) // Created after the children were torn down
+	cas.Kill()
+	if dying == nil || !dying.IsDead() || dying.isCancelled() {
+		t.Error("ChildCascadeDeadParent: Child of a dying Cascade should be killed!")
+	}
+
+	late := cas.ChildCascade()
+	if !late.IsDead() || !didExitBeforeTime(late, time.Second) {
+		t.Error("ChildCascadeDeadParent: Child of a dead Cascade should not be left alive!")
+	}
+
+	cancelled := RootCascade()
+	cancelled.Cancel()
+	if child := cancelled.ChildCascade(); !child.isCancelled() {
+		t.Error("ChildCascadeDeadParent: Child of a cancelled Cascade should be cancelled!")
+	}
+
+	ran := false
+	late.Go(func(c *Cascade) { ran = true })
+	cas.Go(func(c *Cascade) { ran = true })
+	if ran || late.TrackedCount() != 0 {
+		t.Error("ChildCascadeDeadParent: Go should not run on a dead Cascade!")
+	}
+	_, results, errs := GoResult(late, func(c *Cascade) (int, error) { return 1, nil })
+	if err := <-errs; err != ErrParentDead {
+		t.Errorf("ChildCascadeDeadParent: Expected %v, got %v", ErrParentDead, err)
+	}
+	if _, ok := <-results; ok {
+		t.Error("ChildCascadeDeadParent: GoResult should not deliver a value!")
+	}
+}
+
+func TestCascade_ChildCascadeSafe(t *testing.T) {
+	cas := RootCascade()
+	child, err := cas.ChildCascadeSafe(WithName("child"))
+	if err != nil || child == nil || child.Name() != "child" || !child.Alive() {
+		t.Errorf("ChildCascadeSafe: Expected a live child, got %v", err)
+	}
+
+	before := OrphanCount()
+	cas.Kill()
+	if child, err := cas.ChildCascadeSafe(); child != nil || err != ErrParentDead {
+		t.Errorf("ChildCascadeSafe: Expected %v, got %v", ErrParentDead, err)
+	}
+	if OrphanCount() != before {
+		t.Error("ChildCascadeSafe: A rejected child should not be orphaned!")
+	}
+}
+
+func TestCascade_WaitAllDescendantsDone(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	grandchild := child.ChildCascade()
+	release := make(chan struct{})
+	grandchild.DoOnKill(func() { <-release })
+
+	waited := make(chan struct{})
+	go func() {
+		cas.WaitAllDescendantsDone()
+		close(waited)
+	}()
+	go child.Kill()
+
+	select {
+	case <-waited:
+		t.Fatal("WaitAllDescendantsDone: Returned before the descendants were done!")
+	case <-time.After(time.Second / 10):
+	}
+
+	close(release)
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("WaitAllDescendantsDone: Got stuck!")
+	}
+	if cas.IsDead() {
+		t.Error("WaitAllDescendantsDone: Cascade was killed!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_KillCollect(t *testing.T) {
+	cas := RootCascade()
+	cas.SetName("root")
+	child := cas.ChildCascade()
+	child.SetName("child")
+	grandchild := child.ChildCascade()
+	grandchild.SetName("grandchild")
+	cas.ChildCascade().SetName("clean")
+
+	errChild := errors.New("child")
+	errGrandchild := errors.New("grandchild")
+	child.setErrorIfUnset(errChild)
+	grandchild.setErrorIfUnset(errGrandchild)
+
+	errs := cas.KillCollect()
+	if len(errs) != 2 {
+		t.Fatalf("KillCollect: Expected 2 errors, got %v", errs)
+	}
+	var nodeErr *NodeError
+	if !errors.As(errs[0], &nodeErr) || nodeErr.Path != "root/child" || !errors.Is(errs[0], errChild) {
+		t.Errorf("KillCollect: Unexpected first error %v", errs[0])
+	}
+	if !errors.As(errs[1], &nodeErr) || nodeErr.Path != "root/child/grandchild" || !errors.Is(errs[1], errGrandchild) {
+		t.Errorf("KillCollect: Unexpected second error %v", errs[1])
+	}
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_Children(t *testing.T) {
+	cas := RootCascade()
+	if cas.Parent() != nil {
+		t.Error("Children: Root should not have a parent!")
+	}
+	if children := cas.Children(); children == nil || len(children) != 0 {
+		t.Errorf("Children: Expected an empty slice, got %v", children)
+	}
+
+	child1 := cas.ChildCascade()
+	child2 := cas.ChildCascade()
+	children := cas.Children()
+	if len(children) != 2 {
+		t.Fatalf("Children: Expected 2 children, got %v", len(children))
+	}
+	found := map[*Cascade]bool{children[0]: true, children[1]: true}
+	if !found[child1] || !found[child2] {
+		t.Error("Children: Snapshot does not match the children!")
+	}
+	if child1.Parent() != cas {
+		t.Error("Children: Parent does not match!")
+	}
+
+	children[0] = nil
+	if cas.ChildCount() != 2 || cas.Children()[0] == nil {
+		t.Error("Children: Modifying the snapshot changed the Cascade!")
+	}
+	cas.Kill()
+}
+
+func TestCascade_CollectErrors(t *testing.T) {
+	cas := RootCascade()
+	child1 := cas.ChildCascade()
+	child2 := cas.ChildCascade()
+	grandchild := child1.ChildCascade()
+
+	if len(cas.CollectErrors()) != 0 || cas.CollectErrorsJoined() != nil {
+		t.Error("CollectErrors: Expected no errors on a healthy tree!")
+	}
+
+	err1 := errors.New("grandchild")
+	err2 := errors.New("child2")
+	_ = grandchild.KillWithError(err1)
+	_ = child2.KillWithError(err2)
+	cas.Kill()
+
+	errs := cas.CollectErrors()
+	if len(errs) != 2 {
+		t.Fatalf("CollectErrors: Expected 2 errors, got %d!", len(errs))
+	}
+	if errs[0] != err1 || errs[1] != err2 {
+		t.Errorf("CollectErrors: Expected errors in depth-first order, got %v", errs)
+	}
+	joined := cas.CollectErrorsJoined()
+	if !errors.Is(joined, err1) || !errors.Is(joined, err2) {
+		t.Errorf("CollectErrorsJoined: Expected both errors, got %v", joined)
+	}
+}
+
+func TestCascade_KillChildren(t *testing.T) {
+	cas := RootCascade()
+	child1 := cas.ChildCascade()
+	child2 := cas.ChildCascade()
+	grandchild := child1.ChildCascade()
+	ran := false
+	child2.DoOnKill(func() { ran = true })
+
+	cas.KillChildren()
+	if child1.Alive() || child2.Alive() || grandchild.Alive() {
+		t.Error("KillChildren: Children should be dead!")
+	}
+	if !ran {
+		t.Error("KillChildren: Child actions should have run!")
+	}
+	if !cas.Alive() {
+		t.Error("KillChildren: Parent should still be alive!")
+	}
+	if cas.ChildCount() != 0 {
+		t.Errorf("KillChildren: Expected 0 children, got %d", cas.ChildCount())
+	}
+
+	child3 := cas.ChildCascade()
+	if !child3.Alive() || cas.ChildCount() != 1 {
+		t.Error("KillChildren: Should be able to create new children!")
+	}
+	cas.Kill()
+	verifyCascadeEndState(t, cas, false, 0, true, 0, false, 0, false)
+}
+
+func TestCascade_CancelChildren(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	ran := false
+	child.DoOnKill(func() { ran = true })
+
+	cas.CancelChildren()
+	if child.Alive() || ran {
+		t.Error("CancelChildren: Child should be cancelled without running actions!")
+	}
+	if !cas.Alive() || cas.ChildCount() != 0 {
+		t.Error("CancelChildren: Parent should be alive without children!")
+	}
+	if !cas.ChildCascade().Alive() {
+		t.Error("CancelChildren: Should be able to create new children!")
+	}
+	cas.Cancel()
+}
+
+func TestCascade_Depth(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	grandchild := child.ChildCascade()
+	sibling := cas.ChildCascade()
+
+	for i, node := range []*Cascade{cas, child, grandchild, sibling} {
+		want := []int{0, 1, 2, 1}[i]
+		if node.Depth() != want {
+			t.Errorf("Depth: Expected %d, got %d", want, node.Depth())
+		}
+		if node.Root() != cas {
+			t.Error("Root: Every node should have the same root!")
+		}
+		if node.IsRoot() != (i == 0) {
+			t.Errorf("IsRoot: Unexpected result for node %d", i)
+		}
+	}
+	cas.Kill()
+}
+
+func TestCascade_KillCount(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	child.ChildCascade()
+	cas.ChildCascade().Cancel()
+	if child.CancelCount() != 1 || cas.KillCount() != 0 {
+		t.Errorf("KillCount: Expected 0 kills and 1 cancel, got %d and %d", cas.KillCount(), cas.CancelCount())
+	}
+	cas.Kill()
+	if cas.KillCount() != 3 || cas.CancelCount() != 1 {
+		t.Errorf("KillCount: Expected 3 kills and 1 cancel, got %d and %d", cas.KillCount(), cas.CancelCount())
+	}
+}
+
+func TestCascade_Tree(t *testing.T) {
+	cas := RootCascadeNamed("root")
+	pool := cas.ChildCascadeNamed("pool")
+	pool.ChildCascadeNamed("worker")
+	cas.ChildCascade()
+	pool.Mark()
+
+	expected := "root tracked=0 children=2 alive\n" +
+		"  pool tracked=1 children=1 alive\n" +
+		"    worker tracked=0 children=0 alive\n" +
+		"  - tracked=0 children=0 alive\n"
+	if tree := cas.Tree(); tree != expected {
+		t.Errorf("Tree: Expected:\n%s\ngot:\n%s", expected, tree)
+	}
+
+	go pool.Kill()
+	<-pool.Dying()
+	expected = "pool tracked=1 children=0 dead err=\"cascade: killed\"\n"
+	if tree := pool.Tree(); tree != expected {
+		t.Errorf("Tree: Expected:\n%s\ngot:\n%s", expected, tree)
+	}
+	pool.UnMark()
+	cas.Kill()
+	if tree := cas.Tree(); tree != "root tracked=0 children=0 dead err=\"cascade: killed\"\n" {
+		t.Errorf("Tree: Unexpected tree for a dead Cascade:\n%s", tree)
+	}
+}
+
+func TestCascade_Detach(t *testing.T) {
+	cas := RootCascade()
+	child := cas.ChildCascade()
+	grandchild := child.ChildCascade()
+
+	detached, err := child.Detach()
+	if err != nil || detached != child {
+		t.Fatalf("Detach: Unexpected result %v, %v", detached, err)
+	}
+	if !child.IsRoot() || grandchild.Root() != child || cas.ChildCount() != 0 {
+		t.Error("Detach: Child should have become a root!")
+	}
+	if _, err := child.Detach(); err == nil {
+		t.Error("Detach: Detaching a root should fail!")
+	}
+
+	cas.Kill()
+	if !child.Alive() || !grandchild.Alive() {
+		t.Error("Detach: Detached subtree should survive the old parent!")
+	}
+	child.Kill()
+	if grandchild.Alive() {
+		t.Error("Detach: Detached Cascade should still kill its own children!")
+	}
+
+	cas = RootCascade()
+	child = cas.ChildCascade()
+	dead := cas.ChildCascade()
+	dead.Kill()
+	if _, err := dead.Detach(); err == nil {
+		t.Error("Detach: Detaching a dead Cascade should fail!")
+	}
+	var detachErr error
+	cas.DoBeforeKill(func() { _, detachErr = child.Detach() }) // The parent is dead, its children are not yet
+	cas.Kill()
+	if detachErr == nil {
+		t.Error("Detach: Detaching from a dead parent should fail!")
+	}
+}
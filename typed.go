@@ -0,0 +1,69 @@
+package cascade
+
+// GoConsume runs a tracked goroutine that calls the provided handler for every value received from the
+// provided channel.
+//
+// The goroutine exits once the channel is closed or once the Cascade is killed or cancelled, whichever
+// happens first. Values still buffered in the channel when the Cascade starts dying are not handled.
+//
+// The returned Cascade is a child of the provided Cascade that is tracking the goroutine.
+func GoConsume[T any](c *Cascade, ch <-chan T, handle func(T)) *Cascade {
+	return c.Go(func(child *Cascade) {
+		for {
+			select {
+			case <-child.Dying():
+				return
+			case val, ok := <-ch:
+				if !ok {
+					return
+				}
+				handle(val)
+			}
+		}
+	})
+}
+
+// GoResult runs the provided function as a tracked goroutine (just like `Go`) and hands back its outcome.
+// At most one value is sent across the two returned channels: the value on the first channel if the function
+// returned a `nil` error, otherwise the error on the second one. If the function is not run, `ErrDraining`
+// is sent on the error channel when the Cascade is draining (see `Drain`) and `ErrParentDead` when it is
+// dead. If the function panics and the panic is recovered (see `PanicPolicy`), nothing is sent.
+//
+// Both channels are buffered and always closed once the outcome is known, so they can safely be read at any
+// point, including after the Cascade is dead. The function MUST implement an exit condition using the
+// provided Cascade so that the channels are never waited on forever when the Cascade is killed before the
+// function has produced a value.
+//
+// The returned Cascade is a child of the provided Cascade that is tracking the goroutine.
+func GoResult[T any](c *Cascade, f func(*Cascade) (T, error)) (*Cascade, <-chan T, <-chan error) {
+	results := make(chan T, 1)
+	errs := make(chan error, 1)
+	child := c.ChildCascade()
+	launched := child.launch(func() {
+		defer close(errs)
+		defer close(results)
+		ran := false
+		child.Wrap(func(child *Cascade) {
+			ran = true
+			val, err := f(child)
+			if err != nil {
+				errs <- err
+			} else {
+				results <- val
+			}
+		})
+		if !ran { // Wrap does not run the function once the Cascade is draining
+			errs <- ErrDraining
+		}
+	})
+	if !launched {
+		if child.rejected || child.Draining() {
+			errs <- ErrDraining
+		} else {
+			errs <- ErrParentDead
+		}
+		close(errs)
+		close(results)
+	}
+	return child, results, errs
+}
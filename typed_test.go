@@ -0,0 +1,135 @@
+package cascade
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGoConsume(t *testing.T) {
+	cas := RootCascade()
+	ch := make(chan int)
+	mu := sync.Mutex{}
+	sum := 0
+
+	child := GoConsume(cas, ch, func(val int) {
+		mu.Lock()
+		sum += val
+		mu.Unlock()
+	})
+
+	for i := 1; i <= 10; i++ {
+		ch <- i
+	}
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("GoConsume: Got stuck in Kill!")
+	}
+
+	mu.Lock()
+	if sum != 55 {
+		t.Errorf("GoConsume: Expected every value to be handled for a sum of 55, got %v", sum)
+	}
+	mu.Unlock()
+
+	verifyCascadeEndState(t, child, true, 0, true, 0, false, 0, false)
+}
+
+func TestGoConsumeClosed(t *testing.T) {
+	cas := RootCascade()
+	ch := make(chan string, 2)
+	got := make(chan string, 2)
+
+	child := GoConsume(cas, ch, func(val string) {
+		got <- val
+	})
+
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-got:
+		case <-time.After(time.Second):
+			t.Fatal("GoConsumeClosed: Value was not handled!")
+		}
+	}
+
+	// Closing the channel should let the goroutine exit on its own.
+	exited := false
+	for i := 0; i < 100 && !exited; i++ {
+		child.muTracked.RLock()
+		exited = child.tracked.Load() == 0
+		child.muTracked.RUnlock()
+		if !exited {
+			<-time.After(10 * time.Millisecond)
+		}
+	}
+	if !exited {
+		t.Error("GoConsumeClosed: Goroutine did not exit when the channel closed!")
+	}
+	if child.IsDead() {
+		t.Error("GoConsumeClosed: Closing the channel killed the Cascade!")
+	}
+
+	go cas.Kill()
+	if !didExitBeforeTime(cas, 1*time.Second) {
+		t.Error("GoConsumeClosed: Got stuck in Kill!")
+	}
+}
+
+func TestGoResult(t *testing.T) {
+	cas := RootCascade()
+	_, results, errs := GoResult(cas, func(*Cascade) (int, error) {
+		return 42, nil
+	})
+	if val := <-results; val != 42 {
+		t.Errorf("GoResult: Expected 42, got %v", val)
+	}
+	if err, ok := <-errs; ok {
+		t.Errorf("GoResult: Expected no error, got %v", err)
+	}
+	cas.Kill()
+}
+
+func TestGoResultError(t *testing.T) {
+	cas := RootCascade()
+	err := errors.New("failed")
+	child, results, errs := GoResult(cas, func(*Cascade) (string, error) {
+		return "", err
+	})
+	if got := <-errs; got != err {
+		t.Errorf("GoResultError: Expected %v, got %v", err, got)
+	}
+	if _, ok := <-results; ok {
+		t.Error("GoResultError: A result was sent along with the error!")
+	}
+	cas.Kill()
+	if _, ok := <-errs; ok || child.Alive() {
+		t.Error("GoResultError: Channels should stay readable after the Cascade is dead!")
+	}
+}
+
+func TestGoResultKilled(t *testing.T) {
+	cas := RootCascade()
+	_, results, errs := GoResult(cas, func(c *Cascade) (int, error) {
+		<-c.Dying()
+		return 0, errors.New("killed")
+	})
+	cas.Kill()
+
+	select {
+	case err := <-errs:
+		if err == nil || err.Error() != "killed" {
+			t.Errorf("GoResultKilled: Expected the kill error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GoResultKilled: Reading the error blocked!")
+	}
+	if _, ok := <-results; ok {
+		t.Error("GoResultKilled: A result was sent for a killed Cascade!")
+	}
+}
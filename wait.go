@@ -0,0 +1,63 @@
+package cascade
+
+import (
+	"sync"
+	"time"
+)
+
+// WaitAny blocks until the first of the provided Cascades is dead (see `Dead`) and returns it. If several
+// are already dead, any one of them may be returned. `nil` is returned right away if no Cascades are provided.
+//
+// Every Cascade is watched by a goroutine that exits as soon as WaitAny returns.
+func WaitAny(cascades ...*Cascade) *Cascade {
+	if len(cascades) == 0 {
+		return nil
+	}
+	first := make(chan *Cascade, 1)
+	stop := make(chan struct{})
+	defer close(stop)
+	for _, c := range cascades {
+		go func(c *Cascade) {
+			select {
+			case <-c.Dead():
+				select {
+				case first <- c:
+				default: // Another Cascade got there first
+				}
+			case <-stop:
+			}
+		}(c)
+	}
+	return <-first
+}
+
+// WaitAll blocks until every one of the provided Cascades is completely done (see `Done`).
+func WaitAll(cascades ...*Cascade) {
+	<-allDone(cascades)
+}
+
+// WaitAllWithTimeout blocks until every one of the provided Cascades is completely done (just like `WaitAll`)
+// or until the provided duration elapses. Returns `false` if any of the Cascades was not done in time.
+//
+// On a timeout the goroutines watching the remaining Cascades keep running until those are done.
+func WaitAllWithTimeout(d time.Duration, cascades ...*Cascade) bool {
+	return waitWithTimeout(allDone(cascades), d)
+}
+
+// Returns a channel that is closed once every provided Cascade is done
+func allDone(cascades []*Cascade) <-chan struct{} {
+	wg := sync.WaitGroup{}
+	wg.Add(len(cascades))
+	for _, c := range cascades {
+		go func(c *Cascade) {
+			<-c.Done()
+			wg.Done()
+		}(c)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
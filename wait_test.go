@@ -0,0 +1,56 @@
+package cascade
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitAny(t *testing.T) {
+	if WaitAny() != nil {
+		t.Error("WaitAny: Expected nil without Cascades!")
+	}
+
+	cascades := []*Cascade{RootCascade(), RootCascade(), RootCascade()}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cascades[1].Kill()
+	}()
+	if first := WaitAny(cascades...); first != cascades[1] {
+		t.Error("WaitAny: Expected the killed Cascade to be returned!")
+	}
+	if !cascades[0].Alive() || !cascades[2].Alive() {
+		t.Error("WaitAny: Other Cascades should not be affected!")
+	}
+	cascades[0].Kill()
+	cascades[2].Kill()
+}
+
+func TestWaitAll(t *testing.T) {
+	WaitAll() // Returns right away
+
+	cascades := []*Cascade{RootCascade(), RootCascade(), RootCascade()}
+	for _, c := range cascades {
+		go c.Kill()
+	}
+	WaitAll(cascades...)
+	for _, c := range cascades {
+		select {
+		case <-c.Done():
+		default:
+			t.Error("WaitAll: Every Cascade should be done!")
+		}
+	}
+}
+
+func TestWaitAllWithTimeout(t *testing.T) {
+	done := RootCascade()
+	done.Kill()
+	stuck := RootCascade()
+	if !WaitAllWithTimeout(time.Second, done) {
+		t.Error("WaitAllWithTimeout: Expected every Cascade to be done!")
+	}
+	if WaitAllWithTimeout(20*time.Millisecond, done, stuck) {
+		t.Error("WaitAllWithTimeout: Expected a timeout for the stuck Cascade!")
+	}
+	stuck.Kill()
+}